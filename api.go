@@ -0,0 +1,295 @@
+package gopaypal
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// API describes every PayPal operation provided by the client. Code depending on API, or on one of
+// the narrower interfaces it is made of, instead of *Client can be tested with the fakes of the
+// paypalfake package
+type API interface {
+	OAuthAPI
+	IdentityAPI
+	PaymentsAPI
+	PaymentResourcesAPI
+	OrdersAPI
+	OrderPaymentsAPI
+	LinksAPI
+	WebhooksAPI
+	ProductsAPI
+	PlansAPI
+	SubscriptionsAPI
+	BillingPlansAPI
+	BillingAgreementsAPI
+	InvoicesAPI
+	InvoiceTemplatesAPI
+	PayoutsAPI
+}
+
+// OAuthAPI describes the OAuth2 token operations
+type OAuthAPI interface {
+	GetAccessToken() (*OAuthResponse, error)
+	GetAccessTokenWithContext(ctx context.Context) (*OAuthResponse, error)
+	Token() (*OAuthResponse, error)
+	TokenWithContext(ctx context.Context) (*OAuthResponse, error)
+}
+
+// IdentityAPI describes the Log In with PayPal identity operations
+type IdentityAPI interface {
+	GenerateIdentityURL(state string, ret string, scope []string) (*url.URL, error)
+	GetTokenFromRefreshToken(refresh string) (*IdentityAccessTokenResponse, error)
+	GetTokenFromRefreshTokenWithContext(ctx context.Context, refresh string) (*IdentityAccessTokenResponse, error)
+	GetTokenFromIdentityCode(code, ret string) (*IdentityAccessTokenResponse, error)
+	GetTokenFromIdentityCodeWithContext(ctx context.Context, code, ret string) (*IdentityAccessTokenResponse, error)
+	GetUserInfo(tkn string) (*IdentityUserInfoResponse, error)
+	GetUserInfoWithContext(ctx context.Context, tkn string) (*IdentityUserInfoResponse, error)
+}
+
+// PaymentsAPI describes the v1 payment operations
+type PaymentsAPI interface {
+	CreatePayment(payment Payment) (*PaymentResponse, error)
+	CreatePaymentWithContext(ctx context.Context, payment Payment) (*PaymentResponse, error)
+	ExecutePayment(paymentID, payerID string) (*PaymentResponse, error)
+	ExecutePaymentWithContext(ctx context.Context, paymentID, payerID string) (*PaymentResponse, error)
+	PaymentInformation(paymentID string) (*PaymentResponse, error)
+	PaymentInformationWithContext(ctx context.Context, paymentID string) (*PaymentResponse, error)
+	UpdatePayment(paymentID string, patch Patch) (*PaymentResponse, error)
+	UpdatePaymentWithContext(ctx context.Context, paymentID string, patch Patch) (*PaymentResponse, error)
+	ListPayments(params PaymentListParams) (*PaymentList, error)
+	ListPaymentsWithContext(ctx context.Context, params PaymentListParams) (*PaymentList, error)
+	IteratePayments(ctx context.Context, params PaymentListParams) *PaymentIterator
+}
+
+// PaymentResourcesAPI describes the operations on the sales, authorizations, captures and refunds
+// of v1 payments
+type PaymentResourcesAPI interface {
+	GetSale(saleID string) (*Sale, error)
+	GetSaleWithContext(ctx context.Context, saleID string) (*Sale, error)
+	RefundSale(saleID string, req SaleRefundRequest) (*Refund, error)
+	RefundSaleWithContext(ctx context.Context, saleID string, req SaleRefundRequest) (*Refund, error)
+	GetAuthorization(authorizationID string) (*Authorization, error)
+	GetAuthorizationWithContext(ctx context.Context, authorizationID string) (*Authorization, error)
+	CaptureAuthorization(authorizationID string, capture Capture) (*Capture, error)
+	CaptureAuthorizationWithContext(ctx context.Context, authorizationID string, capture Capture) (*Capture, error)
+	VoidAuthorization(authorizationID string) (*Authorization, error)
+	VoidAuthorizationWithContext(ctx context.Context, authorizationID string) (*Authorization, error)
+	GetCapture(captureID string) (*Capture, error)
+	GetCaptureWithContext(ctx context.Context, captureID string) (*Capture, error)
+	RefundCapture(captureID string, req SaleRefundRequest) (*Refund, error)
+	RefundCaptureWithContext(ctx context.Context, captureID string, req SaleRefundRequest) (*Refund, error)
+	GetRefund(refundID string) (*Refund, error)
+	GetRefundWithContext(ctx context.Context, refundID string) (*Refund, error)
+}
+
+// OrdersAPI describes the Orders v2 operations
+type OrdersAPI interface {
+	CreateOrder(order OrderRequest) (*Order, error)
+	CreateOrderWithContext(ctx context.Context, order OrderRequest) (*Order, error)
+	GetOrder(orderID string) (*Order, error)
+	GetOrderWithContext(ctx context.Context, orderID string) (*Order, error)
+	UpdateOrder(orderID string, patch Patch) error
+	UpdateOrderWithContext(ctx context.Context, orderID string, patch Patch) error
+	AuthorizeOrder(orderID string, req AuthorizeOrderRequest) (*Order, error)
+	AuthorizeOrderWithContext(ctx context.Context, orderID string, req AuthorizeOrderRequest) (*Order, error)
+	CaptureOrder(orderID string, req CaptureOrderRequest) (*Order, error)
+	CaptureOrderWithContext(ctx context.Context, orderID string, req CaptureOrderRequest) (*Order, error)
+	ConfirmPaymentSource(orderID string, req ConfirmOrderRequest) (*Order, error)
+	ConfirmPaymentSourceWithContext(ctx context.Context, orderID string, req ConfirmOrderRequest) (*Order, error)
+}
+
+// OrderPaymentsAPI describes the Payments v2 operations on the authorizations, captures and refunds
+// of orders
+type OrderPaymentsAPI interface {
+	GetAuthorizedPayment(authorizationID string) (*AuthorizedPayment, error)
+	GetAuthorizedPaymentWithContext(ctx context.Context, authorizationID string) (*AuthorizedPayment, error)
+	CaptureAuthorizedPayment(authorizationID string, req CaptureAuthorizationRequest) (*CapturedPayment, error)
+	CaptureAuthorizedPaymentWithContext(ctx context.Context, authorizationID string, req CaptureAuthorizationRequest) (*CapturedPayment, error)
+	ReauthorizeAuthorizedPayment(authorizationID string, req ReauthorizeRequest) (*AuthorizedPayment, error)
+	ReauthorizeAuthorizedPaymentWithContext(ctx context.Context, authorizationID string, req ReauthorizeRequest) (*AuthorizedPayment, error)
+	VoidAuthorizedPayment(authorizationID string) error
+	VoidAuthorizedPaymentWithContext(ctx context.Context, authorizationID string) error
+	GetCapturedPayment(captureID string) (*CapturedPayment, error)
+	GetCapturedPaymentWithContext(ctx context.Context, captureID string) (*CapturedPayment, error)
+	RefundCapturedPayment(captureID string, req RefundRequest) (*PaymentRefund, error)
+	RefundCapturedPaymentWithContext(ctx context.Context, captureID string, req RefundRequest) (*PaymentRefund, error)
+	GetPaymentRefund(refundID string) (*PaymentRefund, error)
+	GetPaymentRefundWithContext(ctx context.Context, refundID string) (*PaymentRefund, error)
+}
+
+// LinksAPI describes the HATEOAS link operations
+type LinksAPI interface {
+	FollowLink(ctx context.Context, link Link, in, out interface{}) error
+}
+
+// WebhooksAPI describes the webhook management operations
+type WebhooksAPI interface {
+	CreateWebhook(webhook Webhook) (*Webhook, error)
+	CreateWebhookWithContext(ctx context.Context, webhook Webhook) (*Webhook, error)
+	ListWebhooks() ([]Webhook, error)
+	ListWebhooksWithContext(ctx context.Context) ([]Webhook, error)
+	GetWebhook(webhookID string) (*Webhook, error)
+	GetWebhookWithContext(ctx context.Context, webhookID string) (*Webhook, error)
+	UpdateWebhook(webhookID string, patch Patch) (*Webhook, error)
+	UpdateWebhookWithContext(ctx context.Context, webhookID string, patch Patch) (*Webhook, error)
+	DeleteWebhook(webhookID string) error
+	DeleteWebhookWithContext(ctx context.Context, webhookID string) error
+	ReconcileWebhook(webhook Webhook) (*Webhook, error)
+	ReconcileWebhookWithContext(ctx context.Context, webhook Webhook) (*Webhook, error)
+	ListWebhookEventTypes() ([]WebhookEventType, error)
+	ListWebhookEventTypesWithContext(ctx context.Context) ([]WebhookEventType, error)
+	ListWebhookEvents(params WebhookEventListParams) (*WebhookEventList, error)
+	ListWebhookEventsWithContext(ctx context.Context, params WebhookEventListParams) (*WebhookEventList, error)
+	GetWebhookEvent(eventID string) (*WebhookEvent, error)
+	GetWebhookEventWithContext(ctx context.Context, eventID string) (*WebhookEvent, error)
+	ResendWebhookEvent(eventID string, webhookIDs ...string) (*WebhookEvent, error)
+	ResendWebhookEventWithContext(ctx context.Context, eventID string, webhookIDs ...string) (*WebhookEvent, error)
+	SimulateWebhookEvent(req SimulateWebhookEventRequest) (*WebhookEvent, error)
+	SimulateWebhookEventWithContext(ctx context.Context, req SimulateWebhookEventRequest) (*WebhookEvent, error)
+}
+
+// ProductsAPI describes the Catalog Products API operations
+type ProductsAPI interface {
+	CreateProduct(product Product) (*Product, error)
+	CreateProductWithContext(ctx context.Context, product Product) (*Product, error)
+	ListProducts(params ProductListParams) (*ProductList, error)
+	ListProductsWithContext(ctx context.Context, params ProductListParams) (*ProductList, error)
+	IterateProducts(ctx context.Context, params ProductListParams) *ProductIterator
+	GetProduct(productID string) (*Product, error)
+	GetProductWithContext(ctx context.Context, productID string) (*Product, error)
+	UpdateProduct(productID string, patch Patch) error
+	UpdateProductWithContext(ctx context.Context, productID string, patch Patch) error
+}
+
+// PlansAPI describes the Subscriptions API billing plan operations
+type PlansAPI interface {
+	CreatePlan(plan Plan) (*Plan, error)
+	CreatePlanWithContext(ctx context.Context, plan Plan) (*Plan, error)
+	ListPlans(params PlanListParams) (*PlanList, error)
+	ListPlansWithContext(ctx context.Context, params PlanListParams) (*PlanList, error)
+	GetPlan(planID string) (*Plan, error)
+	GetPlanWithContext(ctx context.Context, planID string) (*Plan, error)
+	UpdatePlanPricing(planID string, schemes []PricingSchemeUpdate) error
+	UpdatePlanPricingWithContext(ctx context.Context, planID string, schemes []PricingSchemeUpdate) error
+	ActivatePlan(planID string) error
+	ActivatePlanWithContext(ctx context.Context, planID string) error
+	DeactivatePlan(planID string) error
+	DeactivatePlanWithContext(ctx context.Context, planID string) error
+}
+
+// SubscriptionsAPI describes the Subscriptions API subscription operations
+type SubscriptionsAPI interface {
+	CreateSubscription(subscription Subscription) (*Subscription, error)
+	CreateSubscriptionWithContext(ctx context.Context, subscription Subscription) (*Subscription, error)
+	GetSubscription(subscriptionID string) (*Subscription, error)
+	GetSubscriptionWithContext(ctx context.Context, subscriptionID string) (*Subscription, error)
+	ReviseSubscription(subscriptionID string, revision SubscriptionRevision) (*SubscriptionRevision, error)
+	ReviseSubscriptionWithContext(ctx context.Context, subscriptionID string, revision SubscriptionRevision) (*SubscriptionRevision, error)
+	SuspendSubscription(subscriptionID, reason string) error
+	SuspendSubscriptionWithContext(ctx context.Context, subscriptionID, reason string) error
+	ActivateSubscription(subscriptionID, reason string) error
+	ActivateSubscriptionWithContext(ctx context.Context, subscriptionID, reason string) error
+	CancelSubscription(subscriptionID, reason string) error
+	CancelSubscriptionWithContext(ctx context.Context, subscriptionID, reason string) error
+	CaptureSubscription(subscriptionID, note string, amount Money) (*SubscriptionTransaction, error)
+	CaptureSubscriptionWithContext(ctx context.Context, subscriptionID, note string, amount Money) (*SubscriptionTransaction, error)
+	ListSubscriptionTransactions(subscriptionID string, start, end time.Time) (*SubscriptionTransactionList, error)
+	ListSubscriptionTransactionsWithContext(ctx context.Context, subscriptionID string, start, end time.Time) (*SubscriptionTransactionList, error)
+}
+
+// BillingPlansAPI describes the legacy v1 billing plan operations
+type BillingPlansAPI interface {
+	CreateBillingPlan(plan BillingPlan) (*BillingPlan, error)
+	CreateBillingPlanWithContext(ctx context.Context, plan BillingPlan) (*BillingPlan, error)
+	ListBillingPlans(params BillingPlanListParams) (*BillingPlanList, error)
+	ListBillingPlansWithContext(ctx context.Context, params BillingPlanListParams) (*BillingPlanList, error)
+	GetBillingPlan(planID string) (*BillingPlan, error)
+	GetBillingPlanWithContext(ctx context.Context, planID string) (*BillingPlan, error)
+	UpdateBillingPlan(planID string, patch Patch) error
+	UpdateBillingPlanWithContext(ctx context.Context, planID string, patch Patch) error
+	ActivateBillingPlan(planID string) error
+	ActivateBillingPlanWithContext(ctx context.Context, planID string) error
+}
+
+// BillingAgreementsAPI describes the legacy v1 billing agreement operations
+type BillingAgreementsAPI interface {
+	CreateBillingAgreement(agreement BillingAgreement) (*BillingAgreement, error)
+	CreateBillingAgreementWithContext(ctx context.Context, agreement BillingAgreement) (*BillingAgreement, error)
+	ExecuteBillingAgreement(token string) (*BillingAgreement, error)
+	ExecuteBillingAgreementWithContext(ctx context.Context, token string) (*BillingAgreement, error)
+	GetBillingAgreement(agreementID string) (*BillingAgreement, error)
+	GetBillingAgreementWithContext(ctx context.Context, agreementID string) (*BillingAgreement, error)
+	SuspendBillingAgreement(agreementID, note string) error
+	SuspendBillingAgreementWithContext(ctx context.Context, agreementID, note string) error
+	ReactivateBillingAgreement(agreementID, note string) error
+	ReactivateBillingAgreementWithContext(ctx context.Context, agreementID, note string) error
+	CancelBillingAgreement(agreementID, note string) error
+	CancelBillingAgreementWithContext(ctx context.Context, agreementID, note string) error
+	SetBillingAgreementBalance(agreementID string, balance Currency) error
+	SetBillingAgreementBalanceWithContext(ctx context.Context, agreementID string, balance Currency) error
+	BillBillingAgreementBalance(agreementID, note string, amount *Currency) error
+	BillBillingAgreementBalanceWithContext(ctx context.Context, agreementID, note string, amount *Currency) error
+	ListBillingAgreementTransactions(agreementID string, start, end time.Time) ([]AgreementTransaction, error)
+	ListBillingAgreementTransactionsWithContext(ctx context.Context, agreementID string, start, end time.Time) ([]AgreementTransaction, error)
+}
+
+// InvoicesAPI describes the Invoicing API invoice operations
+type InvoicesAPI interface {
+	CreateInvoice(invoice Invoice) (*Invoice, error)
+	CreateInvoiceWithContext(ctx context.Context, invoice Invoice) (*Invoice, error)
+	GetInvoice(invoiceID string) (*Invoice, error)
+	GetInvoiceWithContext(ctx context.Context, invoiceID string) (*Invoice, error)
+	DeleteInvoice(invoiceID string) error
+	DeleteInvoiceWithContext(ctx context.Context, invoiceID string) error
+	SendInvoice(invoiceID string, notification InvoiceNotification) (*Link, error)
+	SendInvoiceWithContext(ctx context.Context, invoiceID string, notification InvoiceNotification) (*Link, error)
+	RemindInvoice(invoiceID string, notification InvoiceNotification) error
+	RemindInvoiceWithContext(ctx context.Context, invoiceID string, notification InvoiceNotification) error
+	CancelInvoice(invoiceID string, notification InvoiceNotification) error
+	CancelInvoiceWithContext(ctx context.Context, invoiceID string, notification InvoiceNotification) error
+	RecordInvoicePayment(invoiceID string, payment InvoicePaymentDetail) (string, error)
+	RecordInvoicePaymentWithContext(ctx context.Context, invoiceID string, payment InvoicePaymentDetail) (string, error)
+	RecordInvoiceRefund(invoiceID string, refund InvoiceRefundDetail) (string, error)
+	RecordInvoiceRefundWithContext(ctx context.Context, invoiceID string, refund InvoiceRefundDetail) (string, error)
+	ListInvoices(params InvoiceListParams) (*InvoiceList, error)
+	ListInvoicesWithContext(ctx context.Context, params InvoiceListParams) (*InvoiceList, error)
+	SearchInvoices(search InvoiceSearch, params InvoiceListParams) (*InvoiceList, error)
+	SearchInvoicesWithContext(ctx context.Context, search InvoiceSearch, params InvoiceListParams) (*InvoiceList, error)
+	GenerateNextInvoiceNumber() (string, error)
+	GenerateNextInvoiceNumberWithContext(ctx context.Context) (string, error)
+	GenerateInvoiceQRCode(invoiceID string, req InvoiceQRCodeRequest) ([]byte, error)
+	GenerateInvoiceQRCodeWithContext(ctx context.Context, invoiceID string, req InvoiceQRCodeRequest) ([]byte, error)
+}
+
+// InvoiceTemplatesAPI describes the Invoicing API template operations
+type InvoiceTemplatesAPI interface {
+	CreateInvoiceTemplate(template InvoiceTemplate) (*InvoiceTemplate, error)
+	CreateInvoiceTemplateWithContext(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error)
+	ListInvoiceTemplates() ([]InvoiceTemplate, error)
+	ListInvoiceTemplatesWithContext(ctx context.Context) ([]InvoiceTemplate, error)
+	GetInvoiceTemplate(templateID string) (*InvoiceTemplate, error)
+	GetInvoiceTemplateWithContext(ctx context.Context, templateID string) (*InvoiceTemplate, error)
+	UpdateInvoiceTemplate(templateID string, template InvoiceTemplate) (*InvoiceTemplate, error)
+	UpdateInvoiceTemplateWithContext(ctx context.Context, templateID string, template InvoiceTemplate) (*InvoiceTemplate, error)
+	DeleteInvoiceTemplate(templateID string) error
+	DeleteInvoiceTemplateWithContext(ctx context.Context, templateID string) error
+}
+
+// PayoutsAPI describes the Payouts API operations
+type PayoutsAPI interface {
+	CreatePayout(payout Payout) (*PayoutBatch, error)
+	CreatePayoutWithContext(ctx context.Context, payout Payout) (*PayoutBatch, error)
+	CreatePayouts(payouts []Payout) ([]*PayoutBatch, error)
+	CreatePayoutsWithContext(ctx context.Context, payouts []Payout) ([]*PayoutBatch, error)
+	GetPayoutBatch(batchID string, params PayoutBatchParams) (*PayoutBatch, error)
+	GetPayoutBatchWithContext(ctx context.Context, batchID string, params PayoutBatchParams) (*PayoutBatch, error)
+	IteratePayoutItems(ctx context.Context, batchID string, params PayoutBatchParams) *PayoutItemIterator
+	GetPayoutItem(itemID string) (*PayoutItemDetail, error)
+	GetPayoutItemWithContext(ctx context.Context, itemID string) (*PayoutItemDetail, error)
+	CancelPayoutItem(itemID string) (*PayoutItemDetail, error)
+	CancelPayoutItemWithContext(ctx context.Context, itemID string) (*PayoutItemDetail, error)
+}
+
+// Check the client implements the API interface
+var _ API = (*Client)(nil)
@@ -0,0 +1,455 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	BillingPlanTypeFixed    = "FIXED"
+	BillingPlanTypeInfinite = "INFINITE"
+
+	BillingPlanStateCreated  = "CREATED"
+	BillingPlanStateActive   = "ACTIVE"
+	BillingPlanStateInactive = "INACTIVE"
+
+	BillingAgreementStateActive    = "Active"
+	BillingAgreementStatePending   = "Pending"
+	BillingAgreementStateSuspended = "Suspended"
+	BillingAgreementStateCancelled = "Cancelled"
+	BillingAgreementStateExpired   = "Expired"
+)
+
+// BillingPlan is a legacy v1 billing plan, the template billing agreements are created from. New
+// integrations should use the Subscriptions API Plan instead
+type BillingPlan struct {
+	ID                  string               `json:"id,omitempty"`
+	Name                string               `json:"name,omitempty"`
+	Description         string               `json:"description,omitempty"`
+	Type                string               `json:"type,omitempty"`
+	State               string               `json:"state,omitempty"`
+	PaymentDefinitions  []PaymentDefinition  `json:"payment_definitions,omitempty"`
+	MerchantPreferences *MerchantPreferences `json:"merchant_preferences,omitempty"`
+	CreateTime          string               `json:"create_time,omitempty"`
+	UpdateTime          string               `json:"update_time,omitempty"`
+	Links               Links                `json:"links,omitempty"`
+	RequestID           string               `json:"-"`
+}
+
+// PaymentDefinition is a trial or regular payment of a billing plan. Type and Frequency take the
+// Tenure and Interval constants. The regular definition of INFINITE plans must have Cycles "0", so
+// it bills until the agreement is cancelled. CreateBillingPlan sets it when empty
+type PaymentDefinition struct {
+	ID                string        `json:"id,omitempty"`
+	Name              string        `json:"name,omitempty"`
+	Type              string        `json:"type,omitempty"`
+	Frequency         string        `json:"frequency,omitempty"`
+	FrequencyInterval string        `json:"frequency_interval,omitempty"`
+	Cycles            string        `json:"cycles,omitempty"`
+	Amount            Currency      `json:"amount"`
+	ChargeModels      []ChargeModel `json:"charge_models,omitempty"`
+}
+
+// ChargeModel is a shipping or tax charge added to a payment definition
+type ChargeModel struct {
+	ID     string   `json:"id,omitempty"`
+	Type   string   `json:"type"`
+	Amount Currency `json:"amount"`
+}
+
+// MerchantPreferences holds the setup fee, the redirect URLs and the failed payments handling of a
+// billing plan
+type MerchantPreferences struct {
+	SetupFee                *Currency `json:"setup_fee,omitempty"`
+	ReturnURL               string    `json:"return_url,omitempty"`
+	CancelURL               string    `json:"cancel_url,omitempty"`
+	NotifyURL               string    `json:"notify_url,omitempty"`
+	MaxFailAttempts         string    `json:"max_fail_attempts,omitempty"`
+	AutoBillAmount          string    `json:"auto_bill_amount,omitempty"`
+	InitialFailAmountAction string    `json:"initial_fail_amount_action,omitempty"`
+	AcceptedPaymentType     string    `json:"accepted_payment_type,omitempty"`
+}
+
+// BillingPlanListParams filters and paginates the plans returned by ListBillingPlans. Zero values
+// are not sent, which lists the CREATED plans
+type BillingPlanListParams struct {
+	Status        string
+	Page          int
+	PageSize      int
+	TotalRequired bool
+}
+
+// BillingPlanList is a page of billing plans. The totals are sent as strings by PayPal
+type BillingPlanList struct {
+	Plans      []BillingPlan `json:"plans"`
+	TotalItems string        `json:"total_items,omitempty"`
+	TotalPages string        `json:"total_pages,omitempty"`
+	Links      Links         `json:"links,omitempty"`
+}
+
+// BillingAgreement is a legacy v1 billing agreement of a payer to a billing plan. Only the plan ID
+// is needed to create one. New integrations should use the Subscriptions API Subscription instead
+type BillingAgreement struct {
+	ID                          string               `json:"id,omitempty"`
+	Name                        string               `json:"name,omitempty"`
+	Description                 string               `json:"description,omitempty"`
+	StartDate                   string               `json:"start_date,omitempty"`
+	State                       string               `json:"state,omitempty"`
+	Payer                       *Payer               `json:"payer,omitempty"`
+	ShippingAddress             *AgreementAddress    `json:"shipping_address,omitempty"`
+	OverrideMerchantPreferences *MerchantPreferences `json:"override_merchant_preferences,omitempty"`
+	Plan                        *BillingPlan         `json:"plan,omitempty"`
+	AgreementDetails            *AgreementDetails    `json:"agreement_details,omitempty"`
+	Links                       Links                `json:"links,omitempty"`
+	RequestID                   string               `json:"-"`
+}
+
+// AgreementAddress is the v1 shipping address of a billing agreement
+type AgreementAddress struct {
+	Line1       string `json:"line1"`
+	Line2       string `json:"line2,omitempty"`
+	City        string `json:"city"`
+	State       string `json:"state,omitempty"`
+	PostalCode  string `json:"postal_code,omitempty"`
+	CountryCode string `json:"country_code"`
+}
+
+// AgreementDetails holds the billing state of a billing agreement
+type AgreementDetails struct {
+	OutstandingBalance *Currency `json:"outstanding_balance,omitempty"`
+	CyclesRemaining    string    `json:"cycles_remaining,omitempty"`
+	CyclesCompleted    string    `json:"cycles_completed,omitempty"`
+	NextBillingDate    string    `json:"next_billing_date,omitempty"`
+	LastPaymentDate    string    `json:"last_payment_date,omitempty"`
+	LastPaymentAmount  *Currency `json:"last_payment_amount,omitempty"`
+	FinalPaymentDate   string    `json:"final_payment_date,omitempty"`
+	FailedPaymentCount string    `json:"failed_payment_count,omitempty"`
+}
+
+// AgreementTransaction is a payment or a status change of a billing agreement
+type AgreementTransaction struct {
+	TransactionID   string    `json:"transaction_id"`
+	Status          string    `json:"status"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          *Currency `json:"amount,omitempty"`
+	FeeAmount       *Currency `json:"fee_amount,omitempty"`
+	NetAmount       *Currency `json:"net_amount,omitempty"`
+	PayerEmail      string    `json:"payer_email,omitempty"`
+	PayerName       string    `json:"payer_name,omitempty"`
+	TimeStamp       string    `json:"time_stamp"`
+	TimeZone        string    `json:"time_zone,omitempty"`
+}
+
+// Query returns the list parameters encoded as an URL query
+func (p BillingPlanListParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	if p.TotalRequired {
+		query.Set("total_required", "yes")
+	}
+
+	return query
+}
+
+// LinkByRel returns the billing agreement link with the given relation
+func (a *BillingAgreement) LinkByRel(rel string) (Link, bool) {
+	return a.Links.ByRel(rel)
+}
+
+// ApprovalURL returns the URL the payer must be redirected to in order to approve the billing agreement
+func (a *BillingAgreement) ApprovalURL() string {
+	return a.Links.ApprovalURL()
+}
+
+// Token returns the token of the created billing agreement, found on its approval URL. PayPal
+// sends it back on the return URL once the payer approves the agreement
+func (a *BillingAgreement) Token() string {
+	u, err := url.Parse(a.ApprovalURL())
+
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("token")
+}
+
+// CreateBillingPlan creates a legacy billing plan. Plans must be activated before creating agreements
+func (c Client) CreateBillingPlan(plan BillingPlan) (*BillingPlan, error) {
+	return c.CreateBillingPlanWithContext(context.Background(), plan)
+}
+
+// CreateBillingPlanWithContext creates a legacy billing plan using the given context
+func (c Client) CreateBillingPlanWithContext(ctx context.Context, plan BillingPlan) (*BillingPlan, error) {
+	// Infinite plans bill their regular definition for "0" cycles, which omitempty would drop
+	if plan.Type == BillingPlanTypeInfinite {
+		definitions := make([]PaymentDefinition, len(plan.PaymentDefinitions))
+
+		for i, definition := range plan.PaymentDefinitions {
+			if definition.Type == TenureRegular && definition.Cycles == "" {
+				definition.Cycles = "0"
+			}
+
+			definitions[i] = definition
+		}
+
+		plan.PaymentDefinitions = definitions
+	}
+
+	// Hold plan response
+	d := BillingPlan{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, BillingPlansURL, &plan, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// ListBillingPlans gets a page of the legacy billing plans matching the given parameters
+func (c Client) ListBillingPlans(params BillingPlanListParams) (*BillingPlanList, error) {
+	return c.ListBillingPlansWithContext(context.Background(), params)
+}
+
+// ListBillingPlansWithContext gets a page of the legacy billing plans matching the given parameters
+// using the given context
+func (c Client) ListBillingPlansWithContext(ctx context.Context, params BillingPlanListParams) (*BillingPlanList, error) {
+	endpoint := BillingPlansURL
+
+	if query := params.Query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	// Hold plan list response
+	d := BillingPlanList{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, endpoint, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// GetBillingPlan gets the details of the legacy billing plan with the given ID
+func (c Client) GetBillingPlan(planID string) (*BillingPlan, error) {
+	return c.GetBillingPlanWithContext(context.Background(), planID)
+}
+
+// GetBillingPlanWithContext gets the details of the legacy billing plan with the given ID using the given context
+func (c Client) GetBillingPlanWithContext(ctx context.Context, planID string) (*BillingPlan, error) {
+	// Hold plan response
+	d := BillingPlan{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(BillingPlanURL, planID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// UpdateBillingPlan updates the legacy billing plan with the given ID applying the JSON Patch operations
+func (c Client) UpdateBillingPlan(planID string, patch Patch) error {
+	return c.UpdateBillingPlanWithContext(context.Background(), planID, patch)
+}
+
+// UpdateBillingPlanWithContext updates the legacy billing plan with the given ID applying the JSON
+// Patch operations using the given context
+func (c Client) UpdateBillingPlanWithContext(ctx context.Context, planID string, patch Patch) error {
+	// Validate patch operations
+	if err := patch.Validate(); err != nil {
+		return err
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPatch, fmt.Sprintf(BillingPlanURL, planID), patch, nil)
+
+	return err
+}
+
+// ActivateBillingPlan activates the legacy billing plan with the given ID so agreements can be created
+func (c Client) ActivateBillingPlan(planID string) error {
+	return c.ActivateBillingPlanWithContext(context.Background(), planID)
+}
+
+// ActivateBillingPlanWithContext activates the legacy billing plan with the given ID using the given context
+func (c Client) ActivateBillingPlanWithContext(ctx context.Context, planID string) error {
+	state := map[string]string{"state": BillingPlanStateActive}
+
+	return c.UpdateBillingPlanWithContext(ctx, planID, Patch{}.Replace("/", state))
+}
+
+// CreateBillingAgreement creates a billing agreement to an active legacy billing plan. The payer
+// must approve it on the agreement approval URL before executing it
+func (c Client) CreateBillingAgreement(agreement BillingAgreement) (*BillingAgreement, error) {
+	return c.CreateBillingAgreementWithContext(context.Background(), agreement)
+}
+
+// CreateBillingAgreementWithContext creates a billing agreement to an active legacy billing plan
+// using the given context
+func (c Client) CreateBillingAgreementWithContext(ctx context.Context, agreement BillingAgreement) (*BillingAgreement, error) {
+	return c.agreementRequest(ctx, http.MethodPost, BillingAgreementsURL, &agreement)
+}
+
+// ExecuteBillingAgreement executes the billing agreement approved by the payer, identified by the
+// token sent back on the return URL
+func (c Client) ExecuteBillingAgreement(token string) (*BillingAgreement, error) {
+	return c.ExecuteBillingAgreementWithContext(context.Background(), token)
+}
+
+// ExecuteBillingAgreementWithContext executes the billing agreement approved by the payer using the
+// given context
+func (c Client) ExecuteBillingAgreementWithContext(ctx context.Context, token string) (*BillingAgreement, error) {
+	return c.agreementRequest(ctx, http.MethodPost, fmt.Sprintf(BillingAgreementExecuteURL, token), struct{}{})
+}
+
+// GetBillingAgreement gets the details of the billing agreement with the given ID
+func (c Client) GetBillingAgreement(agreementID string) (*BillingAgreement, error) {
+	return c.GetBillingAgreementWithContext(context.Background(), agreementID)
+}
+
+// GetBillingAgreementWithContext gets the details of the billing agreement with the given ID using
+// the given context
+func (c Client) GetBillingAgreementWithContext(ctx context.Context, agreementID string) (*BillingAgreement, error) {
+	return c.agreementRequest(ctx, http.MethodGet, fmt.Sprintf(BillingAgreementURL, agreementID), nil)
+}
+
+// SuspendBillingAgreement suspends the billing agreement with the given ID with the given note
+func (c Client) SuspendBillingAgreement(agreementID, note string) error {
+	return c.SuspendBillingAgreementWithContext(context.Background(), agreementID, note)
+}
+
+// SuspendBillingAgreementWithContext suspends the billing agreement with the given ID with the given
+// note using the given context
+func (c Client) SuspendBillingAgreementWithContext(ctx context.Context, agreementID, note string) error {
+	return c.agreementAction(ctx, fmt.Sprintf(BillingAgreementSuspendURL, agreementID), note)
+}
+
+// ReactivateBillingAgreement reactivates the suspended billing agreement with the given ID with the given note
+func (c Client) ReactivateBillingAgreement(agreementID, note string) error {
+	return c.ReactivateBillingAgreementWithContext(context.Background(), agreementID, note)
+}
+
+// ReactivateBillingAgreementWithContext reactivates the suspended billing agreement with the given
+// ID with the given note using the given context
+func (c Client) ReactivateBillingAgreementWithContext(ctx context.Context, agreementID, note string) error {
+	return c.agreementAction(ctx, fmt.Sprintf(BillingAgreementReactivateURL, agreementID), note)
+}
+
+// CancelBillingAgreement cancels the billing agreement with the given ID with the given note
+func (c Client) CancelBillingAgreement(agreementID, note string) error {
+	return c.CancelBillingAgreementWithContext(context.Background(), agreementID, note)
+}
+
+// CancelBillingAgreementWithContext cancels the billing agreement with the given ID with the given
+// note using the given context
+func (c Client) CancelBillingAgreementWithContext(ctx context.Context, agreementID, note string) error {
+	return c.agreementAction(ctx, fmt.Sprintf(BillingAgreementCancelURL, agreementID), note)
+}
+
+// SetBillingAgreementBalance sets the outstanding balance of the billing agreement with the given ID
+func (c Client) SetBillingAgreementBalance(agreementID string, balance Currency) error {
+	return c.SetBillingAgreementBalanceWithContext(context.Background(), agreementID, balance)
+}
+
+// SetBillingAgreementBalanceWithContext sets the outstanding balance of the billing agreement with
+// the given ID using the given context
+func (c Client) SetBillingAgreementBalanceWithContext(ctx context.Context, agreementID string, balance Currency) error {
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(BillingAgreementSetBalanceURL, agreementID), &balance, nil)
+
+	return err
+}
+
+// BillBillingAgreementBalance bills the outstanding balance of the billing agreement with the given
+// ID. A nil amount bills the whole balance
+func (c Client) BillBillingAgreementBalance(agreementID, note string, amount *Currency) error {
+	return c.BillBillingAgreementBalanceWithContext(context.Background(), agreementID, note, amount)
+}
+
+// BillBillingAgreementBalanceWithContext bills the outstanding balance of the billing agreement
+// with the given ID using the given context
+func (c Client) BillBillingAgreementBalanceWithContext(ctx context.Context, agreementID, note string, amount *Currency) error {
+	req := struct {
+		Note   string    `json:"note"`
+		Amount *Currency `json:"amount,omitempty"`
+	}{
+		Note:   note,
+		Amount: amount,
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(BillingAgreementBillBalanceURL, agreementID), &req, nil)
+
+	return err
+}
+
+// ListBillingAgreementTransactions gets the transactions of the billing agreement with the given ID
+// made between the given dates
+func (c Client) ListBillingAgreementTransactions(agreementID string, start, end time.Time) ([]AgreementTransaction, error) {
+	return c.ListBillingAgreementTransactionsWithContext(context.Background(), agreementID, start, end)
+}
+
+// ListBillingAgreementTransactionsWithContext gets the transactions of the billing agreement with
+// the given ID made between the given dates using the given context
+func (c Client) ListBillingAgreementTransactionsWithContext(ctx context.Context, agreementID string, start, end time.Time) ([]AgreementTransaction, error) {
+	query := url.Values{}
+
+	query.Set("start_date", start.UTC().Format("2006-01-02"))
+	query.Set("end_date", end.UTC().Format("2006-01-02"))
+
+	// Hold transaction list response
+	d := struct {
+		Transactions []AgreementTransaction `json:"agreement_transaction_list"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(BillingAgreementTransactionsURL, agreementID)+"?"+query.Encode(), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return d.Transactions, nil
+}
+
+// agreementRequest runs a billing agreement request and returns the resulting agreement
+func (c Client) agreementRequest(ctx context.Context, method, endpoint string, in interface{}) (*BillingAgreement, error) {
+	// Hold agreement response
+	d := BillingAgreement{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, in, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// agreementAction runs a billing agreement state change request with the given note
+func (c Client) agreementAction(ctx context.Context, endpoint, note string) error {
+	req := struct {
+		Note string `json:"note"`
+	}{
+		Note: note,
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPost, endpoint, &req, nil)
+
+	return err
+}
@@ -0,0 +1,319 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_CreateBillingPlan(t *testing.T) {
+	var patch Patch
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/payments/billing-plans": func(w http.ResponseWriter, r *http.Request) {
+			plan := BillingPlan{}
+
+			// Infinite plans must bill their regular definition for "0" cycles
+			if err := json.NewDecoder(r.Body).Decode(&plan); err != nil || len(plan.PaymentDefinitions) != 1 || plan.PaymentDefinitions[0].Cycles != "0" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "P-94458432VR012762KRWBZEUA", "name": "Monthly plan", "type": "INFINITE", "state": "CREATED"}`))
+		},
+		"PATCH /v1/payments/billing-plans/P-94458432VR012762KRWBZEUA": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&patch)
+		},
+		"GET /v1/payments/billing-plans": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("status") != BillingPlanStateActive || r.URL.Query().Get("total_required") != "yes" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"plans": [{"id": "P-94458432VR012762KRWBZEUA", "state": "ACTIVE"}], "total_items": "1", "total_pages": "1"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	plan, err := client.CreateBillingPlan(BillingPlan{
+		Name:        "Monthly plan",
+		Description: "Monthly billing plan",
+		Type:        BillingPlanTypeInfinite,
+		PaymentDefinitions: []PaymentDefinition{
+			{
+				Name:              "Regular payments",
+				Type:              TenureRegular,
+				Frequency:         IntervalMonth,
+				FrequencyInterval: "1",
+				Amount:            Currency{Currency: "USD", Value: "10.00"},
+			},
+		},
+		MerchantPreferences: &MerchantPreferences{
+			ReturnURL:      "https://example.com/return",
+			CancelURL:      "https://example.com/cancel",
+			AutoBillAmount: "YES",
+		},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create billing plan: %v", err)
+		t.FailNow()
+	}
+
+	if plan.ID != "P-94458432VR012762KRWBZEUA" || plan.State != BillingPlanStateCreated || plan.RequestID == "" {
+		t.Errorf("Unexpected created billing plan %+v", plan)
+		t.FailNow()
+	}
+
+	if err := client.ActivateBillingPlan(plan.ID); err != nil {
+		t.Errorf("Cannot activate billing plan: %v", err)
+		t.FailNow()
+	}
+
+	// The plan is activated replacing its state
+	if len(patch) != 1 || patch[0].Path != "/" || patch[0].Value.(map[string]interface{})["state"] != BillingPlanStateActive {
+		t.Errorf("Unexpected billing plan patch %+v", patch)
+		t.FailNow()
+	}
+
+	list, err := client.ListBillingPlans(BillingPlanListParams{Status: BillingPlanStateActive, TotalRequired: true})
+
+	if err != nil {
+		t.Errorf("Cannot list billing plans: %v", err)
+		t.FailNow()
+	}
+
+	if len(list.Plans) != 1 || list.TotalItems != "1" {
+		t.Errorf("Unexpected billing plans %+v", list)
+		t.FailNow()
+	}
+}
+
+func TestClient_BillingAgreementLifecycle(t *testing.T) {
+	notes := map[string]string{}
+	balance := Currency{}
+
+	action := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			req := struct {
+				Note string `json:"note"`
+			}{}
+
+			json.NewDecoder(r.Body).Decode(&req)
+			notes[name] = req.Note
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/payments/billing-agreements": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"name": "Monthly agreement",
+				"plan": {"id": "P-94458432VR012762KRWBZEUA"},
+				"links": [
+					{"href": "https://www.sandbox.paypal.com/cgi-bin/webscr?cmd=_express-checkout&token=EC-0JP008296V451950C", "rel": "approval_url", "method": "REDIRECT"},
+					{"href": "https://api.sandbox.paypal.com/v1/payments/billing-agreements/EC-0JP008296V451950C/agreement-execute", "rel": "execute", "method": "POST"}
+				]
+			}`))
+		},
+		"POST /v1/payments/billing-agreements/EC-0JP008296V451950C/agreement-execute": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "I-0LN988D3JACS", "state": "Active", "agreement_details": {"outstanding_balance": {"currency": "USD", "value": "0.00"}}}`))
+		},
+		"POST /v1/payments/billing-agreements/I-0LN988D3JACS/suspend":      action("suspend"),
+		"POST /v1/payments/billing-agreements/I-0LN988D3JACS/re-activate":  action("reactivate"),
+		"POST /v1/payments/billing-agreements/I-0LN988D3JACS/cancel":       action("cancel"),
+		"POST /v1/payments/billing-agreements/I-0LN988D3JACS/bill-balance": action("bill"),
+		"POST /v1/payments/billing-agreements/I-0LN988D3JACS/set-balance": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&balance)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /v1/payments/billing-agreements/I-0LN988D3JACS/transactions": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("start_date") != "2018-01-21" || r.URL.Query().Get("end_date") != "2018-04-21" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"agreement_transaction_list": [
+				{"transaction_id": "I-0LN988D3JACS", "status": "Created", "transaction_type": "Recurring Payment", "time_stamp": "2018-01-21T07:50:20Z"}
+			]}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	agreement, err := client.CreateBillingAgreement(BillingAgreement{
+		Name:        "Monthly agreement",
+		Description: "Monthly billing agreement",
+		StartDate:   "2018-01-21T07:50:20Z",
+		Payer:       &Payer{PaymentMethod: "paypal"},
+		Plan:        &BillingPlan{ID: "P-94458432VR012762KRWBZEUA"},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create billing agreement: %v", err)
+		t.FailNow()
+	}
+
+	if agreement.ApprovalURL() == "" || agreement.Token() != "EC-0JP008296V451950C" {
+		t.Errorf("Unexpected created billing agreement %+v", agreement)
+		t.FailNow()
+	}
+
+	// Execute the approved agreement
+	agreement, err = client.ExecuteBillingAgreement(agreement.Token())
+
+	if err != nil {
+		t.Errorf("Cannot execute billing agreement: %v", err)
+		t.FailNow()
+	}
+
+	if agreement.ID != "I-0LN988D3JACS" || agreement.State != BillingAgreementStateActive {
+		t.Errorf("Unexpected executed billing agreement %+v", agreement)
+		t.FailNow()
+	}
+
+	// Change the agreement state
+	if err := client.SuspendBillingAgreement(agreement.ID, "Suspending the agreement"); err != nil {
+		t.Errorf("Cannot suspend billing agreement: %v", err)
+		t.FailNow()
+	}
+
+	if err := client.ReactivateBillingAgreement(agreement.ID, "Reactivating the agreement"); err != nil {
+		t.Errorf("Cannot reactivate billing agreement: %v", err)
+		t.FailNow()
+	}
+
+	// Manage the outstanding balance
+	if err := client.SetBillingAgreementBalance(agreement.ID, Currency{Currency: "USD", Value: "10.00"}); err != nil {
+		t.Errorf("Cannot set billing agreement balance: %v", err)
+		t.FailNow()
+	}
+
+	if err := client.BillBillingAgreementBalance(agreement.ID, "Billing the balance", nil); err != nil {
+		t.Errorf("Cannot bill billing agreement balance: %v", err)
+		t.FailNow()
+	}
+
+	if err := client.CancelBillingAgreement(agreement.ID, "Cancelling the agreement"); err != nil {
+		t.Errorf("Cannot cancel billing agreement: %v", err)
+		t.FailNow()
+	}
+
+	if balance.Value != "10.00" || notes["suspend"] != "Suspending the agreement" || notes["bill"] != "Billing the balance" || len(notes) != 4 {
+		t.Errorf("Unexpected billing agreement changes %v %+v", notes, balance)
+		t.FailNow()
+	}
+
+	// List transactions
+	start := time.Date(2018, 1, 21, 7, 50, 20, 0, time.UTC)
+
+	txs, err := client.ListBillingAgreementTransactions(agreement.ID, start, start.AddDate(0, 3, 0))
+
+	if err != nil {
+		t.Errorf("Cannot list billing agreement transactions: %v", err)
+		t.FailNow()
+	}
+
+	if len(txs) != 1 || txs[0].TransactionType != "Recurring Payment" {
+		t.Errorf("Unexpected billing agreement transactions %+v", txs)
+		t.FailNow()
+	}
+}
+
+func TestClient_ManageBillingPlan(t *testing.T) {
+	var patch Patch
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/payments/billing-plans/P-94458432VR012762KRWBZEUA": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"id": "P-94458432VR012762KRWBZEUA",
+				"name": "Fast Speed Plan",
+				"type": "INFINITE",
+				"state": "ACTIVE",
+				"payment_definitions": [{"id": "PD-50606817NF8063316RWBZEUA", "type": "REGULAR", "frequency": "MONTH", "frequency_interval": "1", "cycles": "0", "amount": {"currency": "USD", "value": "100"}}]
+			}`))
+		},
+		"PATCH /v1/payments/billing-plans/P-94458432VR012762KRWBZEUA": func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		},
+		"GET /v1/payments/billing-agreements/I-0LN988D3JACS": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"id": "I-0LN988D3JACS",
+				"state": "Active",
+				"plan": {"payment_definitions": [{"type": "REGULAR", "frequency": "Month", "cycles": "0", "amount": {"currency": "USD", "value": "100.00"}}]},
+				"agreement_details": {"outstanding_balance": {"currency": "USD", "value": "0.00"}, "cycles_completed": "1", "next_billing_date": "2017-06-17T10:00:00Z"}
+			}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get billing plan
+	plan, err := client.GetBillingPlan("P-94458432VR012762KRWBZEUA")
+
+	if err != nil {
+		t.Errorf("Cannot get billing plan: %v", err)
+		t.FailNow()
+	}
+
+	if plan.State != BillingPlanStateActive || len(plan.PaymentDefinitions) != 1 || plan.PaymentDefinitions[0].Cycles != "0" {
+		t.Errorf("Unexpected billing plan %+v", plan)
+		t.FailNow()
+	}
+
+	// Update billing plan
+	if err := client.UpdateBillingPlan(plan.ID, Patch{}.Replace("/merchant-preferences", MerchantPreferences{SetupFee: &Currency{Currency: "USD", Value: "1"}})); err != nil {
+		t.Errorf("Cannot update billing plan: %v", err)
+		t.FailNow()
+	}
+
+	if len(patch) != 1 || patch[0].Op != "replace" || patch[0].Path != "/merchant-preferences" {
+		t.Errorf("Unexpected billing plan patch %+v", patch)
+		t.FailNow()
+	}
+
+	// Invalid patches are not sent
+	patch = nil
+
+	if err := client.UpdateBillingPlan(plan.ID, Patch{}); err == nil || patch != nil {
+		t.Errorf("Expected an error updating a billing plan with an empty patch")
+		t.FailNow()
+	}
+
+	// Get billing agreement
+	agreement, err := client.GetBillingAgreement("I-0LN988D3JACS")
+
+	if err != nil {
+		t.Errorf("Cannot get billing agreement: %v", err)
+		t.FailNow()
+	}
+
+	if agreement.State != "Active" || agreement.AgreementDetails == nil || agreement.AgreementDetails.CyclesCompleted != "1" {
+		t.Errorf("Unexpected billing agreement %+v", agreement)
+		t.FailNow()
+	}
+
+	if agreement.AgreementDetails.OutstandingBalance == nil || agreement.AgreementDetails.OutstandingBalance.Value != "0.00" {
+		t.Errorf("Unexpected billing agreement balance %+v", agreement.AgreementDetails)
+		t.FailNow()
+	}
+}
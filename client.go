@@ -2,47 +2,117 @@ package gopaypal
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"github.com/kataras/go-errors"
 	"io/ioutil"
 	"net/http"
-	"time"
+	"strings"
 )
 
-type PayPalError struct {
-	Name             string `json:"name"`
-	Message          string `json:"message"`
-	ErrorDescription string `json:"error_description"`
-}
+// accessTokenKey is the context key marking requests authorized with the client access token
+type accessTokenKey struct{}
 
 // Client gopaypal client for communicating with the PayPal REST API endpoints
 type Client struct {
-	baseURL     string
-	clientID    string
-	secret      string
-	AccessToken *oauthResponse
+	baseURL    string
+	clientID   string
+	secret     string
+	httpClient *http.Client
+	retry      RetryPolicy
+	tokens     *tokenManager
+
+	// AccessToken is the last token returned by GetAccessToken on this copy of the client. It is
+	// not updated when the shared token is refreshed
+	//
+	// Deprecated: use Token instead
+	AccessToken *OAuthResponse
 }
 
-// NewClient creates and returns a new gopaypal client with the given credentials
-func NewClient(clientID, secret, base string) Client {
-	return Client{
+// NewClient creates and returns a new gopaypal client with the given credentials and options
+func NewClient(clientID, secret, base string, opts ...ClientOption) Client {
+	c := Client{
 		baseURL:     base,
 		clientID:    clientID,
 		secret:      secret,
-		AccessToken: &oauthResponse{},
+		httpClient:  &http.Client{},
+		tokens:      newTokenManager(TokenStoreKey(clientID, base)),
+		AccessToken: &OAuthResponse{},
 	}
+
+	// Apply client options
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
 }
 
-// Execute runs the given HTTP request
+// Execute runs the given HTTP request, retrying it according to the client retry policy.
+// Unsuccessful responses are returned as a *PayPalError. If the request context is cancelled
+// or its deadline is exceeded the context error is returned, so callers can check it with errors.Is
 func (c Client) Execute(req *http.Request) ([]byte, error) {
-	// Create a new HTTP client
-	client := http.Client{}
+	// Only refresh an early expired access token once per request
+	refreshed := false
+
+	for attempt := 1; ; attempt++ {
+		// Execute request
+		b, res, err := c.execute(req)
+
+		if err == nil {
+			return b, nil
+		}
+
+		// Check if the request can be sent again
+		if !canRewind(req) || req.Context().Err() != nil {
+			return nil, err
+		}
+
+		if res != nil && res.StatusCode == http.StatusUnauthorized && !refreshed && c.ownsAccessToken(req) {
+			refreshed = true
+
+			// Get new access token as the current one expired early
+			tkn, err := c.tokens.renew(req.Context(), strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "), c.requestAccessToken)
+
+			if err != nil {
+				return nil, err
+			}
+
+			// Set authorization header
+			req.Header.Set("Authorization", "Bearer "+tkn.AccessToken)
+		} else {
+			// Check if the failure is transient and there are attempts left
+			if attempt >= c.retry.attempts() || !c.retry.retryable(req, res) {
+				return nil, err
+			}
+
+			// Wait before the next attempt
+			if err := sleep(req.Context(), c.retry.delay(attempt, res)); err != nil {
+				return nil, err
+			}
+		}
+
+		// Rewind request body
+		if err := rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// execute runs the given HTTP request once. The response is returned along with the error
+// when the PayPal endpoint answered with an unsuccessful status
+func (c Client) execute(req *http.Request) ([]byte, *http.Response, error) {
+	// Get the HTTP client
+	client := c.httpClient
+
+	if client == nil {
+		client = http.DefaultClient
+	}
 
 	// Execute request
 	res, err := client.Do(req)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, contextError(req.Context(), err)
 	}
 
 	// Close response body
@@ -52,32 +122,51 @@ func (c Client) Execute(req *http.Request) ([]byte, error) {
 	b, err := ioutil.ReadAll(res.Body)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, contextError(req.Context(), err)
 	}
 
 	// If invalid request parse error
-	if res.StatusCode != 200 && res.StatusCode != 201 {
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+
+		e := PayPalError{
+			StatusCode: res.StatusCode,
+			Body:       b,
+		}
 
-		e := PayPalError{}
+		// Unmarshal error message. Non JSON bodies (e.g. gateway HTML pages) keep the raw body only
+		json.Unmarshal(b, &e)
 
-		// Unmarshal error message
-		if err := json.Unmarshal(b, &e); err != nil {
-			return nil, err
+		// Fall back to the debug ID header
+		if e.DebugID == "" {
+			e.DebugID = res.Header.Get("Paypal-Debug-Id")
 		}
 
-		return nil, errors.New(e.Message)
+		return nil, res, &e
 	}
 
-	return b, nil
+	return b, res, nil
+}
+
+// ownsAccessToken reports whether the request is authorized with the client access token
+func (c Client) ownsAccessToken(req *http.Request) bool {
+	owned, _ := req.Context().Value(accessTokenKey{}).(bool)
+
+	return owned
 }
 
 // BasicRequest creates a basic request to the PayPal endpoint without the Authorization header
 func (c Client) BasicRequest(endpoint string, b []byte, method string) (*http.Request, error) {
+	return c.BasicRequestWithContext(context.Background(), endpoint, b, method)
+}
+
+// BasicRequestWithContext creates a basic request bound to the given context to the PayPal endpoint
+// without the Authorization header
+func (c Client) BasicRequestWithContext(ctx context.Context, endpoint string, b []byte, method string) (*http.Request, error) {
 	// Wrap byte array on a io.Reader
 	buff := bytes.NewBuffer(b)
 
 	// Create HTTP request
-	req, err := http.NewRequest(method, c.baseURL+endpoint, buff)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, buff)
 
 	if err != nil {
 		return nil, err
@@ -94,24 +183,99 @@ func (c Client) BasicRequest(endpoint string, b []byte, method string) (*http.Re
 
 // AuthRequest creates a basic request to the PayPal endpoint with the Authorization header set
 func (c *Client) AuthRequest(endpoint string, b []byte, method string) (*http.Request, error) {
+	return c.AuthRequestWithContext(context.Background(), endpoint, b, method)
+}
+
+// AuthRequestWithContext creates a basic request bound to the given context to the PayPal endpoint
+// with the Authorization header set. Mutating requests carry a PayPal-Request-Id header taken from
+// the context (see ContextWithRequestID) or generated when absent
+func (c *Client) AuthRequestWithContext(ctx context.Context, endpoint string, b []byte, method string) (*http.Request, error) {
 	// Create basic request
-	req, err := c.BasicRequest(endpoint, b, method)
+	req, err := c.BasicRequestWithContext(ctx, endpoint, b, method)
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if access token is expired
-	if time.Now().After(c.AccessToken.Expires) {
+	// Get access token, refreshing it when expired
+	tkn, err := c.tokens.get(ctx, c.requestAccessToken)
 
-		// Get new access token
-		if _, err := c.GetAccessToken(); err != nil {
-			return nil, err
-		}
+	if err != nil {
+		return nil, err
 	}
 
+	// Mark the request as authorized with the client access token
+	req = req.WithContext(context.WithValue(req.Context(), accessTokenKey{}, true))
+
 	// Set authorization header
-	req.Header.Set("Authorization", "Bearer "+c.AccessToken.AccessToken)
+	req.Header.Set("Authorization", "Bearer "+tkn.AccessToken)
+
+	// Set idempotency key header
+	if method == http.MethodPost || method == http.MethodPatch {
+		id := RequestIDFromContext(ctx)
+
+		if id == "" {
+			id = CreateRequestID()
+		}
+
+		req.Header.Set(RequestIDHeader, id)
+	}
 
 	return req, nil
 }
+
+// contextError returns the context error when the given context is done, otherwise it returns err
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
+
+// JSONRequest runs an authorized request to the PayPal endpoint sending in as the JSON request body
+// and unmarshalling the JSON response into out. Nil in or out values are skipped. It returns the
+// PayPal-Request-Id sent with mutating requests
+func (c *Client) JSONRequest(ctx context.Context, method, endpoint string, in, out interface{}) (string, error) {
+	var buff []byte
+
+	// Marshal request body
+	if in != nil {
+		b, err := json.Marshal(in)
+
+		if err != nil {
+			return "", err
+		}
+
+		buff = b
+	}
+
+	// Create auth request
+	req, err := c.AuthRequestWithContext(ctx, endpoint, buff, method)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Set content type
+	req.Header.Set("Content-Type", "application/json")
+
+	// Ask for the full resource representation on mutating calls
+	req.Header.Set("Prefer", "return=representation")
+
+	// Execute request
+	res, err := c.Execute(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Unmarshal response
+	if out != nil && len(res) > 0 {
+		if err := json.Unmarshal(res, out); err != nil {
+			return "", err
+		}
+	}
+
+	return req.Header.Get(RequestIDHeader), nil
+}
@@ -0,0 +1,95 @@
+package gopaypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_ExecuteWithContext(t *testing.T) {
+	// Create a server slower than the request deadline
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Create a context with a short deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+	defer cancel()
+
+	// Try to get access token
+	_, err := client.GetAccessTokenWithContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error. Got %v expected %v", err, context.DeadlineExceeded)
+		t.FailNow()
+	}
+
+	// Create an already cancelled context
+	ctx, cancel = context.WithCancel(context.Background())
+
+	cancel()
+
+	// Try to get payment information
+	_, err = client.PaymentInformationWithContext(ctx, "PAY-1")
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error. Got %v expected %v", err, context.Canceled)
+		t.FailNow()
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	// Create a shared HTTP client
+	shared := &http.Client{}
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, SandBoxURL, WithHTTPClient(shared), WithTimeout(time.Second))
+
+	if client.httpClient.Timeout != time.Second {
+		t.Errorf("Unexpected client timeout. Got %v expected %v", client.httpClient.Timeout, time.Second)
+		t.FailNow()
+	}
+
+	// Shared HTTP client must not be modified
+	if shared.Timeout != 0 {
+		t.Error("Shared HTTP client was modified")
+		t.FailNow()
+	}
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	// Create a fake PayPal server
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token": "A21AA", "token_type": "Bearer"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client using the test server transport
+	client := NewClient(clientID, secret, srv.URL, WithTransport(srv.Client().Transport))
+
+	// Try to get access token
+	res, err := client.GetAccessToken()
+
+	if err != nil {
+		t.Errorf("Cannot get PayPal OAuth access token: %v", err)
+		t.FailNow()
+	}
+
+	if res.AccessToken != "A21AA" {
+		t.Errorf("Unexpected access token. Got %v expected %v", res.AccessToken, "A21AA")
+		t.FailNow()
+	}
+}
@@ -1,16 +1,94 @@
 package gopaypal
 
 const (
-	SandBoxURL          = "https://api.sandbox.paypal.com/"
-	LiveURL             = "https://api.paypal.com"
-	IdentitySandBoxURL  = "https://www.sandbox.paypal.com"
-	IdentityLiveURL     = "https://www.paypal.com"
-	IdentityUserInfoURL = "/v1/identity/openidconnect/userinfo/?schema=openid"
-	PaymentCreateURL    = "/v1/payments/payment"
-	PaymentExecuteURL   = "/v1/payments/payment/%v/execute"
-	PaymentInfoURL      = "/v1/payments/payment/%v"
-	OAuthURL            = "/v1/oauth2/token"
-	IdentityURL         = "/signin/authorize"
-	IdentityTokenURL    = "/v1/identity/openidconnect/tokenservice"
-	nonceLength         = 7
+	SandBoxURL                      = "https://api.sandbox.paypal.com/"
+	LiveURL                         = "https://api.paypal.com"
+	IdentitySandBoxURL              = "https://www.sandbox.paypal.com"
+	IdentityLiveURL                 = "https://www.paypal.com"
+	IdentityUserInfoURL             = "/v1/identity/openidconnect/userinfo/?schema=openid"
+	PaymentListURL                  = "/v1/payments/payment"
+	PaymentCreateURL                = "/v1/payments/payment"
+	PaymentExecuteURL               = "/v1/payments/payment/%v/execute"
+	PaymentUpdateURL                = "/v1/payments/payment/%v"
+	PaymentInfoURL                  = "/v1/payments/payment/%v"
+	SaleURL                         = "/v1/payments/sale/%v"
+	SaleRefundURL                   = "/v1/payments/sale/%v/refund"
+	AuthorizationURL                = "/v1/payments/authorization/%v"
+	AuthorizationCaptureURL         = "/v1/payments/authorization/%v/capture"
+	AuthorizationVoidURL            = "/v1/payments/authorization/%v/void"
+	CaptureURL                      = "/v1/payments/capture/%v"
+	CaptureRefundURL                = "/v1/payments/capture/%v/refund"
+	RefundURL                       = "/v1/payments/refund/%v"
+	OAuthURL                        = "/v1/oauth2/token"
+	OrdersURL                       = "/v2/checkout/orders"
+	OrderURL                        = "/v2/checkout/orders/%v"
+	OrderAuthorizeURL               = "/v2/checkout/orders/%v/authorize"
+	OrderCaptureURL                 = "/v2/checkout/orders/%v/capture"
+	OrderConfirmURL                 = "/v2/checkout/orders/%v/confirm-payment-source"
+	AuthorizedPaymentURL            = "/v2/payments/authorizations/%v"
+	AuthorizedPaymentCaptureURL     = "/v2/payments/authorizations/%v/capture"
+	AuthorizedPaymentReauthorizeURL = "/v2/payments/authorizations/%v/reauthorize"
+	AuthorizedPaymentVoidURL        = "/v2/payments/authorizations/%v/void"
+	CapturedPaymentURL              = "/v2/payments/captures/%v"
+	CapturedPaymentRefundURL        = "/v2/payments/captures/%v/refund"
+	PaymentRefundURL                = "/v2/payments/refunds/%v"
+	BillingPlansURL                 = "/v1/payments/billing-plans"
+	BillingPlanURL                  = "/v1/payments/billing-plans/%v"
+	BillingAgreementsURL            = "/v1/payments/billing-agreements"
+	BillingAgreementURL             = "/v1/payments/billing-agreements/%v"
+	BillingAgreementExecuteURL      = "/v1/payments/billing-agreements/%v/agreement-execute"
+	BillingAgreementSuspendURL      = "/v1/payments/billing-agreements/%v/suspend"
+	BillingAgreementReactivateURL   = "/v1/payments/billing-agreements/%v/re-activate"
+	BillingAgreementCancelURL       = "/v1/payments/billing-agreements/%v/cancel"
+	BillingAgreementSetBalanceURL   = "/v1/payments/billing-agreements/%v/set-balance"
+	BillingAgreementBillBalanceURL  = "/v1/payments/billing-agreements/%v/bill-balance"
+	BillingAgreementTransactionsURL = "/v1/payments/billing-agreements/%v/transactions"
+	PayoutsURL                      = "/v1/payments/payouts"
+	PayoutURL                       = "/v1/payments/payouts/%v"
+	PayoutItemURL                   = "/v1/payments/payouts-item/%v"
+	PayoutItemCancelURL             = "/v1/payments/payouts-item/%v/cancel"
+	ProductsURL                     = "/v1/catalogs/products"
+	ProductURL                      = "/v1/catalogs/products/%v"
+	PlansURL                        = "/v1/billing/plans"
+	PlanURL                         = "/v1/billing/plans/%v"
+	PlanActivateURL                 = "/v1/billing/plans/%v/activate"
+	PlanDeactivateURL               = "/v1/billing/plans/%v/deactivate"
+	PlanUpdatePricingURL            = "/v1/billing/plans/%v/update-pricing-schemes"
+	SubscriptionsURL                = "/v1/billing/subscriptions"
+	SubscriptionURL                 = "/v1/billing/subscriptions/%v"
+	SubscriptionReviseURL           = "/v1/billing/subscriptions/%v/revise"
+	SubscriptionSuspendURL          = "/v1/billing/subscriptions/%v/suspend"
+	SubscriptionActivateURL         = "/v1/billing/subscriptions/%v/activate"
+	SubscriptionCancelURL           = "/v1/billing/subscriptions/%v/cancel"
+	SubscriptionCaptureURL          = "/v1/billing/subscriptions/%v/capture"
+	SubscriptionTransactionsURL     = "/v1/billing/subscriptions/%v/transactions"
+	InvoicesURL                     = "/v2/invoicing/invoices"
+	InvoiceURL                      = "/v2/invoicing/invoices/%v"
+	InvoiceSendURL                  = "/v2/invoicing/invoices/%v/send"
+	InvoiceRemindURL                = "/v2/invoicing/invoices/%v/remind"
+	InvoiceCancelURL                = "/v2/invoicing/invoices/%v/cancel"
+	InvoicePaymentsURL              = "/v2/invoicing/invoices/%v/payments"
+	InvoiceRefundsURL               = "/v2/invoicing/invoices/%v/refunds"
+	InvoiceQRCodeURL                = "/v2/invoicing/invoices/%v/generate-qr-code"
+	InvoiceSearchURL                = "/v2/invoicing/search-invoices"
+	InvoiceNextNumberURL            = "/v2/invoicing/generate-next-invoice-number"
+	InvoiceTemplatesURL             = "/v2/invoicing/templates"
+	InvoiceTemplateURL              = "/v2/invoicing/templates/%v"
+	WebhooksURL                     = "/v1/notifications/webhooks"
+	WebhookURL                      = "/v1/notifications/webhooks/%v"
+	WebhookEventTypesURL            = "/v1/notifications/webhooks-event-types"
+	WebhookEventsURL                = "/v1/notifications/webhooks-events"
+	WebhookEventURL                 = "/v1/notifications/webhooks-events/%v"
+	WebhookEventResendURL           = "/v1/notifications/webhooks-events/%v/resend"
+	SimulateWebhookEventURL         = "/v1/notifications/simulate-event"
+	IdentityURL                     = "/signin/authorize"
+	IdentityTokenURL                = "/v1/identity/openidconnect/tokenservice"
+	RequestIDHeader                 = "PayPal-Request-Id"
+	TransmissionIDHeader            = "Paypal-Transmission-Id"
+	TransmissionTimeHeader          = "Paypal-Transmission-Time"
+	TransmissionSigHeader           = "Paypal-Transmission-Sig"
+	CertURLHeader                   = "Paypal-Cert-Url"
+	AuthAlgoHeader                  = "Paypal-Auth-Algo"
+	nonceLength                     = 7
+	requestIDLength                 = 32
 )
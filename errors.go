@@ -0,0 +1,104 @@
+package gopaypal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PayPalError is returned by the client when the PayPal endpoint responds with an unsuccessful status
+type PayPalError struct {
+	StatusCode       int           `json:"-"`
+	Name             string        `json:"name"`
+	Message          string        `json:"message"`
+	DebugID          string        `json:"debug_id"`
+	InformationLink  string        `json:"information_link"`
+	Details          []ErrorDetail `json:"details"`
+	Links            Links         `json:"links"`
+	ErrorCode        string        `json:"error"`
+	ErrorDescription string        `json:"error_description"`
+	Body             []byte        `json:"-"`
+}
+
+// ErrorDetail describes a single issue of a failed PayPal request
+type ErrorDetail struct {
+	Field       string `json:"field"`
+	Value       string `json:"value"`
+	Location    string `json:"location"`
+	Issue       string `json:"issue"`
+	Description string `json:"description"`
+}
+
+// Error returns the PayPal error message
+func (e *PayPalError) Error() string {
+	// Get the most descriptive message available
+	msg := e.Message
+
+	if msg == "" {
+		msg = e.ErrorDescription
+	}
+
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	// Get the error name
+	name := e.Name
+
+	if name == "" {
+		name = e.ErrorCode
+	}
+
+	if name != "" {
+		msg = name + ": " + msg
+	}
+
+	if e.DebugID != "" {
+		msg = fmt.Sprintf("%v (debug_id %v)", msg, e.DebugID)
+	}
+
+	return msg
+}
+
+// IsNotFound reports whether the error is a PayPal error for a missing resource
+func IsNotFound(err error) bool {
+	return matchPayPalError(err, []int{http.StatusNotFound}, "RESOURCE_NOT_FOUND", "INVALID_RESOURCE_ID")
+}
+
+// IsValidation reports whether the error is a PayPal error for an invalid request
+func IsValidation(err error) bool {
+	return matchPayPalError(err, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, "VALIDATION_ERROR", "INVALID_REQUEST", "UNPROCESSABLE_ENTITY")
+}
+
+// IsAuth reports whether the error is a PayPal error for invalid credentials or missing permissions
+func IsAuth(err error) bool {
+	return matchPayPalError(err, []int{http.StatusUnauthorized, http.StatusForbidden}, "AUTHENTICATION_FAILURE", "NOT_AUTHORIZED", "PERMISSION_DENIED", "invalid_client", "invalid_token")
+}
+
+// IsRateLimited reports whether the error is a PayPal error for too many requests
+func IsRateLimited(err error) bool {
+	return matchPayPalError(err, []int{http.StatusTooManyRequests}, "RATE_LIMIT_REACHED")
+}
+
+// matchPayPalError reports whether the error is a PayPal error with one of the given status codes or names
+func matchPayPalError(err error, codes []int, names ...string) bool {
+	var e *PayPalError
+
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	for _, code := range codes {
+		if e.StatusCode == code {
+			return true
+		}
+	}
+
+	for _, name := range names {
+		if e.Name == name || e.ErrorCode == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+package gopaypal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ExecuteError(t *testing.T) {
+	// Create a fake PayPal server returning a validation error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Paypal-Debug-Id", "f0d1e2")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"name": "VALIDATION_ERROR",
+			"message": "Invalid request - see details",
+			"details": [{"field": "transactions[0].amount.total", "issue": "Required field missing"}]
+		}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Try to get access token
+	_, err := client.GetAccessToken()
+
+	var e *PayPalError
+
+	if !errors.As(err, &e) {
+		t.Errorf("Unexpected error type %T", err)
+		t.FailNow()
+	}
+
+	if e.StatusCode != http.StatusBadRequest || e.DebugID != "f0d1e2" {
+		t.Errorf("Unexpected error status %v and debug ID %v", e.StatusCode, e.DebugID)
+		t.FailNow()
+	}
+
+	if len(e.Details) != 1 || e.Details[0].Field != "transactions[0].amount.total" {
+		t.Errorf("Unexpected error details %v", e.Details)
+		t.FailNow()
+	}
+
+	if !IsValidation(err) || IsNotFound(err) || IsAuth(err) || IsRateLimited(err) {
+		t.Error("Wrong error class")
+		t.FailNow()
+	}
+}
+
+func TestClient_ExecuteErrorNonJSON(t *testing.T) {
+	// Create a fake gateway returning an HTML page
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Try to get access token
+	_, err := client.GetAccessToken()
+
+	var e *PayPalError
+
+	if !errors.As(err, &e) {
+		t.Errorf("Unexpected error type %T", err)
+		t.FailNow()
+	}
+
+	if e.StatusCode != http.StatusBadGateway || string(e.Body) != "<html>Bad Gateway</html>" {
+		t.Errorf("Unexpected error status %v and body %s", e.StatusCode, e.Body)
+		t.FailNow()
+	}
+
+	if e.Error() != http.StatusText(http.StatusBadGateway) {
+		t.Errorf("Unexpected error message %v", e.Error())
+		t.FailNow()
+	}
+}
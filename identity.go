@@ -1,6 +1,7 @@
 package gopaypal
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"strings"
 )
 
+// IdentityAccessTokenResponse is an access token issued on behalf of a PayPal user
 type IdentityAccessTokenResponse struct {
 	baseURL      string `json:"-"`
 	TokenType    string `json:"token_type"`
@@ -16,6 +18,7 @@ type IdentityAccessTokenResponse struct {
 	AccessToken  string `json:"access_token"`
 }
 
+// IdentityUserInfoResponse holds the profile attributes of a PayPal user
 type IdentityUserInfoResponse struct {
 	UserID          string          `json:"user_id"`
 	Sub             string          `json:"sub"`
@@ -95,6 +98,12 @@ func (c Client) GenerateIdentityURL(state string, ret string, scope []string) (*
 
 // GetTokenFromRefreshToken returns the access token from the given identity refresh token
 func (c *Client) GetTokenFromRefreshToken(refresh string) (*IdentityAccessTokenResponse, error) {
+	return c.GetTokenFromRefreshTokenWithContext(context.Background(), refresh)
+}
+
+// GetTokenFromRefreshTokenWithContext returns the access token from the given identity refresh token
+// using the given context
+func (c *Client) GetTokenFromRefreshTokenWithContext(ctx context.Context, refresh string) (*IdentityAccessTokenResponse, error) {
 	// Set grant_type
 	buff := []byte(fmt.Sprintf(
 		"grant_type=%v&refresh_token=%v",
@@ -103,7 +112,7 @@ func (c *Client) GetTokenFromRefreshToken(refresh string) (*IdentityAccessTokenR
 	))
 
 	// Create new gopaypal basic request
-	req, err := c.BasicRequest(IdentityTokenURL, buff, http.MethodPost)
+	req, err := c.BasicRequestWithContext(ctx, IdentityTokenURL, buff, http.MethodPost)
 
 	if err != nil {
 		return nil, err
@@ -112,6 +121,9 @@ func (c *Client) GetTokenFromRefreshToken(refresh string) (*IdentityAccessTokenR
 	// Set basic HTTP authentication
 	req.SetBasicAuth(c.clientID, c.secret)
 
+	// Set form content type
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
 	// Execute request
 	res, err := c.Execute(req)
 
@@ -134,6 +146,12 @@ func (c *Client) GetTokenFromRefreshToken(refresh string) (*IdentityAccessTokenR
 
 // GetTokenFromIdentityCode returns the access token from the given identity login code
 func (c *Client) GetTokenFromIdentityCode(code, ret string) (*IdentityAccessTokenResponse, error) {
+	return c.GetTokenFromIdentityCodeWithContext(context.Background(), code, ret)
+}
+
+// GetTokenFromIdentityCodeWithContext returns the access token from the given identity login code
+// using the given context
+func (c *Client) GetTokenFromIdentityCodeWithContext(ctx context.Context, code, ret string) (*IdentityAccessTokenResponse, error) {
 	// Set grant_type
 	buff := []byte(fmt.Sprintf(
 		"grant_type=%v&code=%v&redirect_uri=%v",
@@ -143,7 +161,7 @@ func (c *Client) GetTokenFromIdentityCode(code, ret string) (*IdentityAccessToke
 	))
 
 	// Create new gopaypal basic request
-	req, err := c.BasicRequest(IdentityTokenURL, buff, http.MethodPost)
+	req, err := c.BasicRequestWithContext(ctx, IdentityTokenURL, buff, http.MethodPost)
 
 	if err != nil {
 		return nil, err
@@ -152,6 +170,9 @@ func (c *Client) GetTokenFromIdentityCode(code, ret string) (*IdentityAccessToke
 	// Set basic HTTP authentication
 	req.SetBasicAuth(c.clientID, c.secret)
 
+	// Set form content type
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
 	// Execute request
 	res, err := c.Execute(req)
 
@@ -174,8 +195,13 @@ func (c *Client) GetTokenFromIdentityCode(code, ret string) (*IdentityAccessToke
 
 // GetUserInfo gets user profile attributes by the given access token
 func (c Client) GetUserInfo(tkn string) (*IdentityUserInfoResponse, error) {
+	return c.GetUserInfoWithContext(context.Background(), tkn)
+}
+
+// GetUserInfoWithContext gets user profile attributes by the given access token using the given context
+func (c Client) GetUserInfoWithContext(ctx context.Context, tkn string) (*IdentityUserInfoResponse, error) {
 	// Create new gopaypal basic request
-	req, err := c.BasicRequest(IdentityUserInfoURL, nil, http.MethodPost)
+	req, err := c.BasicRequestWithContext(ctx, IdentityUserInfoURL, nil, http.MethodPost)
 
 	if err != nil {
 		return nil, err
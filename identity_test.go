@@ -76,22 +76,16 @@ func TestClient_GetTokenFromIdentityCode(t *testing.T) {
 	}
 
 	// Test get user info call
-	getUserInfoFromAccessToken(client, t)
+	getUserInfoFromAccessToken(client, res.AccessToken, t)
 
 	// Test refresh token call
 	getTokenFromRefreshTokenTest(client, res.RefreshToken, t)
 
 }
 
-func getUserInfoFromAccessToken(client Client, t *testing.T) {
-	// Get application access token first
-	if _, err := client.GetAccessToken(); err != nil {
-		t.Errorf("Cannot get access token: %v", err)
-		t.FailNow()
-	}
-
+func getUserInfoFromAccessToken(client Client, tkn string, t *testing.T) {
 	// Get user information attributes
-	info, err := client.GetUserInfo()
+	info, err := client.GetUserInfo(tkn)
 
 	if err != nil {
 		t.Errorf("Cannot get user info attributes from access token: %v", err)
@@ -0,0 +1,376 @@
+// Command fakegen generates the in-memory fakes of the paypalfake package from the interfaces
+// declared in the gopaypal api.go file. Every interface named with the API suffix gets a fake
+// struct named after it without the suffix, and the API interface itself gets a fake embedding them
+//
+//	go run ./internal/fakegen -in api.go -out paypalfake/fakes.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	pkgName    = "gopaypal"
+	pkgPath    = "github.com/joseluis2g/gopaypal"
+	ctxSuffix  = "WithContext"
+	apiSuffix  = "API"
+	apiName    = "API"
+	contextPkg = "context"
+)
+
+// method is an interface method to fake
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+// param is a method parameter
+type param struct {
+	name string
+	typ  string
+}
+
+// fake is a fake struct generated for an interface
+type fake struct {
+	name    string
+	iface   string
+	methods []method
+}
+
+func main() {
+	in := flag.String("in", "api.go", "file declaring the gopaypal interfaces")
+	out := flag.String("out", "paypalfake/fakes.go", "generated fakes file")
+
+	flag.Parse()
+
+	// Parse interfaces file
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Collect import paths by package name
+	imports := map[string]string{}
+
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+
+		imports[name] = path
+	}
+
+	g := generator{
+		imports: imports,
+		used:    map[string]bool{contextPkg: true},
+	}
+
+	fakes := []fake{}
+
+	// Collect interfaces
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+
+		if !ok {
+			return true
+		}
+
+		iface, ok := spec.Type.(*ast.InterfaceType)
+
+		if !ok || spec.Name.Name == apiName || !strings.HasSuffix(spec.Name.Name, apiSuffix) {
+			return false
+		}
+
+		f := fake{
+			name:  strings.TrimSuffix(spec.Name.Name, apiSuffix),
+			iface: spec.Name.Name,
+		}
+
+		for _, m := range iface.Methods.List {
+			fn, ok := m.Type.(*ast.FuncType)
+
+			if !ok {
+				continue
+			}
+
+			f.methods = append(f.methods, g.method(m.Names[0].Name, fn))
+		}
+
+		fakes = append(fakes, f)
+
+		return false
+	})
+
+	// Generate source
+	body := bytes.Buffer{}
+
+	for _, f := range fakes {
+		g.fake(&body, f)
+	}
+
+	g.api(&body, fakes)
+
+	src := bytes.Buffer{}
+
+	fmt.Fprintf(&src, "// Code generated by fakegen. DO NOT EDIT.\n\npackage paypalfake\n\nimport (\n")
+
+	paths := []string{}
+
+	for name := range g.used {
+		if path, ok := g.imports[name]; ok {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+
+	fmt.Fprintf(&src, "\n\t%q\n)\n", pkgPath)
+
+	src.Write(body.Bytes())
+
+	// Format generated source
+	b, err := format.Source(src.Bytes())
+
+	if err != nil {
+		log.Fatalf("cannot format generated source: %v\n%s", err, src.Bytes())
+	}
+
+	if err := ioutil.WriteFile(*out, b, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generator holds the state needed to write the fakes
+type generator struct {
+	imports map[string]string
+	used    map[string]bool
+}
+
+// method converts an interface method declaration
+func (g *generator) method(name string, fn *ast.FuncType) method {
+	m := method{
+		name: name,
+	}
+
+	for i, field := range fn.Params.List {
+		typ := g.expr(field.Type)
+
+		if len(field.Names) == 0 {
+			m.params = append(m.params, param{name: fmt.Sprintf("p%v", i), typ: typ})
+		}
+
+		for _, n := range field.Names {
+			m.params = append(m.params, param{name: n.Name, typ: typ})
+		}
+	}
+
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			n := len(field.Names)
+
+			if n == 0 {
+				n = 1
+			}
+
+			for i := 0; i < n; i++ {
+				m.results = append(m.results, g.expr(field.Type))
+			}
+		}
+	}
+
+	return m
+}
+
+// expr returns the type expression qualified for the paypalfake package
+func (g *generator) expr(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return pkgName + "." + t.Name
+		}
+
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + g.expr(t.X)
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		g.used[pkg] = true
+
+		return pkg + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + g.expr(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.expr(t.Key) + "]" + g.expr(t.Value)
+	case *ast.Ellipsis:
+		return "..." + g.expr(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.FuncType:
+		m := g.method("", t)
+		return "func" + m.signature()
+	}
+
+	log.Fatalf("unsupported type expression %T", e)
+
+	return ""
+}
+
+// fake writes the fake struct of the given interface
+func (g *generator) fake(w *bytes.Buffer, f fake) {
+	names := map[string]bool{}
+
+	for _, m := range f.methods {
+		names[m.name] = true
+	}
+
+	fmt.Fprintf(w, "\n// %v is an in-memory fake of gopaypal.%v. Every call is recorded and served by the\n", f.name, f.iface)
+	fmt.Fprintf(w, "// matching Func field. Calls without a Func field set fail with ErrUnexpectedCall\n")
+	fmt.Fprintf(w, "type %v struct {\n", f.name)
+
+	for _, m := range f.methods {
+		if names[m.name+ctxSuffix] {
+			continue
+		}
+
+		fmt.Fprintf(w, "\t%vFunc func%v\n", strings.TrimSuffix(m.name, ctxSuffix), m.signature())
+	}
+
+	fmt.Fprintf(w, "\n\tRecorder\n}\n")
+
+	for _, m := range f.methods {
+		fmt.Fprintf(w, "\n// %v records the call and serves it with the %vFunc field\n", m.name, strings.TrimSuffix(m.name, ctxSuffix))
+		fmt.Fprintf(w, "func (f *%v) %v%v {\n", f.name, m.name, m.signature())
+
+		// Plain variants delegate on their context variant
+		if names[m.name+ctxSuffix] {
+			args := []string{contextPkg + ".Background()"}
+
+			for _, p := range m.params {
+				args = append(args, p.arg())
+			}
+
+			fmt.Fprintf(w, "\treturn f.%v%v(%v)\n}\n", m.name, ctxSuffix, strings.Join(args, ", "))
+			continue
+		}
+
+		name := strings.TrimSuffix(m.name, ctxSuffix)
+		args := []string{}
+		recorded := []string{strconv.Quote(name)}
+
+		for _, p := range m.params {
+			args = append(args, p.arg())
+
+			if p.typ != contextPkg+".Context" {
+				recorded = append(recorded, p.name)
+			}
+		}
+
+		fmt.Fprintf(w, "\tf.Record(%v)\n\n", strings.Join(recorded, ", "))
+		fmt.Fprintf(w, "\tif f.%vFunc == nil {\n", name)
+
+		zero := []string{}
+
+		for _, r := range m.results {
+			zero = append(zero, zeroValue(r))
+		}
+
+		if len(m.results) > 0 && m.results[len(m.results)-1] == "error" {
+			zero[len(zero)-1] = fmt.Sprintf("unexpectedCall(%q)", f.name+"."+name)
+		}
+
+		if len(zero) > 0 {
+			fmt.Fprintf(w, "\t\treturn %v\n\t}\n\n", strings.Join(zero, ", "))
+			fmt.Fprintf(w, "\treturn f.%vFunc(%v)\n}\n", name, strings.Join(args, ", "))
+		} else {
+			fmt.Fprintf(w, "\t\treturn\n\t}\n\n")
+			fmt.Fprintf(w, "\tf.%vFunc(%v)\n}\n", name, strings.Join(args, ", "))
+		}
+	}
+}
+
+// api writes the fake of the whole API interface
+func (g *generator) api(w *bytes.Buffer, fakes []fake) {
+	fmt.Fprintf(w, "\n// API is an in-memory fake of gopaypal.API made of the fakes of its narrower interfaces\n")
+	fmt.Fprintf(w, "type API struct {\n")
+
+	for _, f := range fakes {
+		fmt.Fprintf(w, "\t%v\n", f.name)
+	}
+
+	fmt.Fprintf(w, "}\n\n// Check the fakes implement their interfaces\nvar (\n")
+	fmt.Fprintf(w, "\t_ gopaypal.API = (*API)(nil)\n")
+
+	for _, f := range fakes {
+		fmt.Fprintf(w, "\t_ gopaypal.%v = (*%v)(nil)\n", f.iface, f.name)
+	}
+
+	fmt.Fprintf(w, ")\n")
+}
+
+// signature returns the method parameters and results
+func (m method) signature() string {
+	params := []string{}
+
+	for _, p := range m.params {
+		params = append(params, p.name+" "+p.typ)
+	}
+
+	s := "(" + strings.Join(params, ", ") + ")"
+
+	switch len(m.results) {
+	case 0:
+		return s
+	case 1:
+		return s + " " + m.results[0]
+	}
+
+	return s + " (" + strings.Join(m.results, ", ") + ")"
+}
+
+// arg returns the parameter used as a call argument
+func (p param) arg() string {
+	if strings.HasPrefix(p.typ, "...") {
+		return p.name + "..."
+	}
+
+	return p.name
+}
+
+// zeroValue returns the zero value expression of the given type
+func zeroValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["),
+		strings.HasPrefix(typ, "func"), typ == "interface{}", typ == "error":
+		return "nil"
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"):
+		return "0"
+	}
+
+	return typ + "{}"
+}
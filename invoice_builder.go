@@ -0,0 +1,611 @@
+package gopaypal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quantityScale is the number of decimals supported on item quantities
+const quantityScale = 5
+
+// percentScale is the number of decimals supported on tax and discount percentages
+const percentScale = 3
+
+// InvoiceBuilder builds a draft invoice computing its item total, discounts, taxes and total the
+// way PayPal does, so the invoice amount is known before creating it. Amounts are strings in the
+// currency format used by Item and Details, e.g. "10.00"
+//
+//	invoice, err := NewInvoiceBuilder("USD").
+//		Number("INV-1").
+//		Recipient(BillingInfo{EmailAddress: "customer@example.com"}).
+//		AddItem(InvoiceItem{Name: "Yoga mat", Quantity: "2", UnitAmount: Money{Value: "50.00"}}).
+//		Discount("10").
+//		Build()
+//
+// Errors, e.g. malformed amounts, are reported by Build
+type InvoiceBuilder struct {
+	invoice        Invoice
+	discount       *InvoiceDiscount
+	shipping       *InvoiceShipping
+	custom         *CustomAmount
+	beforeDiscount bool
+	err            error
+}
+
+// NewInvoiceBuilder returns a builder of a draft invoice in the given currency
+func NewInvoiceBuilder(currency string) *InvoiceBuilder {
+	return &InvoiceBuilder{
+		invoice: Invoice{
+			Detail:        &InvoiceDetail{CurrencyCode: currency},
+			Configuration: &InvoiceConfiguration{},
+		},
+	}
+}
+
+// Number sets the invoice number. GenerateNextInvoiceNumber returns the next free one
+func (b *InvoiceBuilder) Number(number string) *InvoiceBuilder {
+	b.invoice.Detail.InvoiceNumber = number
+	return b
+}
+
+// Reference sets the invoice reference, e.g. a purchase order number
+func (b *InvoiceBuilder) Reference(reference string) *InvoiceBuilder {
+	b.invoice.Detail.Reference = reference
+	return b
+}
+
+// Date sets the invoice date
+func (b *InvoiceBuilder) Date(date time.Time) *InvoiceBuilder {
+	b.invoice.Detail.InvoiceDate = date.Format("2006-01-02")
+	return b
+}
+
+// PaymentTerm sets the invoice payment term, one of the PaymentTerm constants
+func (b *InvoiceBuilder) PaymentTerm(term string) *InvoiceBuilder {
+	b.invoice.Detail.PaymentTerm = &PaymentTerm{TermType: term}
+	return b
+}
+
+// DueDate sets the date the invoice must be paid by
+func (b *InvoiceBuilder) DueDate(date time.Time) *InvoiceBuilder {
+	b.invoice.Detail.PaymentTerm = &PaymentTerm{
+		TermType: PaymentTermDueOnDateSpecified,
+		DueDate:  date.Format("2006-01-02"),
+	}
+
+	return b
+}
+
+// Note sets the note shown to the recipient
+func (b *InvoiceBuilder) Note(note string) *InvoiceBuilder {
+	b.invoice.Detail.Note = note
+	return b
+}
+
+// Terms sets the invoice terms and conditions
+func (b *InvoiceBuilder) Terms(terms string) *InvoiceBuilder {
+	b.invoice.Detail.TermsAndConditions = terms
+	return b
+}
+
+// Memo sets the private note only shown to the invoicer
+func (b *InvoiceBuilder) Memo(memo string) *InvoiceBuilder {
+	b.invoice.Detail.Memo = memo
+	return b
+}
+
+// Invoicer sets the merchant issuing the invoice
+func (b *InvoiceBuilder) Invoicer(invoicer Invoicer) *InvoiceBuilder {
+	b.invoice.Invoicer = &invoicer
+	return b
+}
+
+// Recipient adds a recipient billed by the invoice
+func (b *InvoiceBuilder) Recipient(billing BillingInfo) *InvoiceBuilder {
+	b.invoice.PrimaryRecipients = append(b.invoice.PrimaryRecipients, RecipientInfo{BillingInfo: &billing})
+	return b
+}
+
+// CC adds an email address receiving a copy of the invoice
+func (b *InvoiceBuilder) CC(email string) *InvoiceBuilder {
+	b.invoice.AdditionalRecipients = append(b.invoice.AdditionalRecipients, EmailRecipient{EmailAddress: email})
+	return b
+}
+
+// AddItem adds an item to the invoice. The item currency defaults to the invoice currency
+func (b *InvoiceBuilder) AddItem(item InvoiceItem) *InvoiceBuilder {
+	if item.UnitAmount.CurrencyCode == "" {
+		item.UnitAmount.CurrencyCode = b.invoice.Detail.CurrencyCode
+	}
+
+	b.invoice.Items = append(b.invoice.Items, item)
+
+	return b
+}
+
+// AddItemList adds the items of a v1 payment item list to the invoice. The per unit item tax is
+// turned into the equivalent tax percentage, and items whose tax is not an exact percentage with up
+// to 3 decimals are rejected
+func (b *InvoiceBuilder) AddItemList(list ItemList) *InvoiceBuilder {
+	decimals := currencyDecimals(b.invoice.Detail.CurrencyCode)
+
+	for _, item := range list.Items {
+		it := InvoiceItem{
+			Name:        item.Name,
+			Description: item.Description,
+			Quantity:    strconv.Itoa(item.Quantity),
+			UnitAmount:  Money{CurrencyCode: item.Currency, Value: item.Price},
+		}
+
+		if item.Tax != "" {
+			percent, err := taxPercent(item.Price, item.Tax, decimals)
+
+			if err != nil {
+				b.fail(fmt.Errorf("item %q: %v", item.Name, err))
+				continue
+			}
+
+			if percent != "" {
+				it.Tax = &InvoiceTax{Name: "Tax", Percent: percent}
+			}
+		}
+
+		b.AddItem(it)
+	}
+
+	return b
+}
+
+// Discount sets an invoice discount of the given percentage of the discounted item total
+func (b *InvoiceBuilder) Discount(percent string) *InvoiceBuilder {
+	b.discount = &InvoiceDiscount{Percent: percent}
+	return b
+}
+
+// DiscountAmount sets an invoice discount of the given amount
+func (b *InvoiceBuilder) DiscountAmount(value string) *InvoiceBuilder {
+	b.discount = &InvoiceDiscount{Amount: &Money{CurrencyCode: b.invoice.Detail.CurrencyCode, Value: value}}
+	return b
+}
+
+// TaxCalculatedBeforeDiscount makes the taxes apply to the item amounts before discounts. By
+// default taxes apply to the discounted item amounts, as PayPal does
+func (b *InvoiceBuilder) TaxCalculatedBeforeDiscount() *InvoiceBuilder {
+	b.beforeDiscount = true
+	return b
+}
+
+// Shipping sets the invoice shipping cost along with its optional tax
+func (b *InvoiceBuilder) Shipping(value string, tax *InvoiceTax) *InvoiceBuilder {
+	b.shipping = &InvoiceShipping{
+		Amount: &Money{CurrencyCode: b.invoice.Detail.CurrencyCode, Value: value},
+		Tax:    tax,
+	}
+
+	return b
+}
+
+// Custom sets a labelled amount added to the invoice total, or subtracted when negative
+func (b *InvoiceBuilder) Custom(label, value string) *InvoiceBuilder {
+	b.custom = &CustomAmount{
+		Label:  label,
+		Amount: &Money{CurrencyCode: b.invoice.Detail.CurrencyCode, Value: value},
+	}
+
+	return b
+}
+
+// Build returns the invoice with the tax amounts of its items and its amount breakdown calculated
+func (b *InvoiceBuilder) Build() (*Invoice, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(b.invoice.Items) == 0 {
+		return nil, fmt.Errorf("invoice without items")
+	}
+
+	currency := b.invoice.Detail.CurrencyCode
+	decimals := currencyDecimals(currency)
+
+	// Copy the invoice so the builder can be reused
+	invoice := b.invoice
+	detail, config := *b.invoice.Detail, *b.invoice.Configuration
+
+	invoice.Detail, invoice.Configuration = &detail, &config
+
+	// Only send how taxes are calculated when it differs from the PayPal default
+	if b.beforeDiscount {
+		afterDiscount := false
+		config.TaxCalculatedAfterDiscount = &afterDiscount
+	}
+
+	invoice.Items = make([]InvoiceItem, len(b.invoice.Items))
+
+	amounts := make([]int64, len(invoice.Items))
+	nets := make([]int64, len(invoice.Items))
+
+	var itemTotal, itemDiscount int64
+
+	// Calculate item amounts and discounts
+	for i, item := range b.invoice.Items {
+		if item.UnitAmount.CurrencyCode != currency {
+			return nil, fmt.Errorf("item %q: currency %v does not match the invoice currency %v", item.Name, item.UnitAmount.CurrencyCode, currency)
+		}
+
+		unit, err := parseDecimal(item.UnitAmount.Value, decimals)
+
+		if err != nil {
+			return nil, fmt.Errorf("item %q: invalid unit amount: %v", item.Name, err)
+		}
+
+		quantity, err := parseDecimal(item.Quantity, quantityScale)
+
+		if err != nil {
+			return nil, fmt.Errorf("item %q: invalid quantity: %v", item.Name, err)
+		}
+
+		amounts[i] = roundDiv(unit*quantity, pow10(quantityScale))
+
+		discount, err := discountAmount(item.Discount, amounts[i], decimals)
+
+		if err != nil {
+			return nil, fmt.Errorf("item %q: invalid discount: %v", item.Name, err)
+		}
+
+		nets[i] = amounts[i] - discount
+		itemTotal += amounts[i]
+		itemDiscount += discount
+
+		invoice.Items[i] = item
+	}
+
+	// Calculate the invoice discount over the discounted item total
+	netTotal := itemTotal - itemDiscount
+
+	invoiceDiscount, err := discountAmount(b.discount, netTotal, decimals)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice discount: %v", err)
+	}
+
+	shares := discountShares(invoiceDiscount, nets, netTotal)
+
+	var taxTotal int64
+
+	// Calculate item taxes
+	for i, item := range invoice.Items {
+		if item.Tax == nil {
+			continue
+		}
+
+		base := nets[i] - shares[i]
+
+		if b.beforeDiscount {
+			base = amounts[i]
+		}
+
+		tax, err := taxAmount(item.Tax, base, currency, decimals)
+
+		if err != nil {
+			return nil, fmt.Errorf("item %q: invalid tax: %v", item.Name, err)
+		}
+
+		invoice.Items[i].Tax = tax
+		taxTotal += parseMinor(tax.Amount.Value, decimals)
+	}
+
+	breakdown := &InvoiceAmountBreakdown{
+		ItemTotal: &Money{CurrencyCode: currency, Value: formatDecimal(itemTotal, decimals)},
+	}
+
+	total := netTotal - invoiceDiscount
+
+	if itemDiscount > 0 || b.discount != nil {
+		breakdown.Discount = &InvoiceDiscounts{InvoiceDiscount: b.discount}
+
+		if itemDiscount > 0 {
+			breakdown.Discount.ItemDiscount = &Money{CurrencyCode: currency, Value: formatDecimal(itemDiscount, decimals)}
+		}
+	}
+
+	// Calculate shipping and its tax
+	if b.shipping != nil {
+		shipping, err := parseDecimal(b.shipping.Amount.Value, decimals)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid shipping amount: %v", err)
+		}
+
+		breakdown.Shipping = &InvoiceShipping{Amount: b.shipping.Amount}
+		total += shipping
+
+		if b.shipping.Tax != nil {
+			tax, err := taxAmount(b.shipping.Tax, shipping, currency, decimals)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid shipping tax: %v", err)
+			}
+
+			breakdown.Shipping.Tax = tax
+			taxTotal += parseMinor(tax.Amount.Value, decimals)
+		}
+	}
+
+	if taxTotal != 0 {
+		breakdown.TaxTotal = &Money{CurrencyCode: currency, Value: formatDecimal(taxTotal, decimals)}
+		total += taxTotal
+	}
+
+	// Add the custom amount
+	if b.custom != nil {
+		custom, err := parseDecimal(b.custom.Amount.Value, decimals)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom amount: %v", err)
+		}
+
+		breakdown.Custom = b.custom
+		total += custom
+	}
+
+	if total < 0 {
+		return nil, fmt.Errorf("negative invoice total %v", formatDecimal(total, decimals))
+	}
+
+	// Skip the configuration when nothing is set
+	if config == (InvoiceConfiguration{}) {
+		invoice.Configuration = nil
+	}
+
+	invoice.Amount = &InvoiceAmount{
+		CurrencyCode: currency,
+		Value:        formatDecimal(total, decimals),
+		Breakdown:    breakdown,
+	}
+
+	return &invoice, nil
+}
+
+// PaymentAmount returns the invoice amount as a v1 payment amount. Discounts and custom amounts
+// are included in the subtotal
+func (a *InvoiceAmount) PaymentAmount() (Amount, error) {
+	decimals := currencyDecimals(a.CurrencyCode)
+
+	total, err := parseDecimal(a.Value, decimals)
+
+	if err != nil {
+		return Amount{}, err
+	}
+
+	details := Details{}
+	subtotal := total
+
+	if a.Breakdown != nil && a.Breakdown.TaxTotal != nil {
+		details.Tax = a.Breakdown.TaxTotal.Value
+		subtotal -= parseMinor(details.Tax, decimals)
+	}
+
+	if a.Breakdown != nil && a.Breakdown.Shipping != nil && a.Breakdown.Shipping.Amount != nil {
+		details.Shipping = a.Breakdown.Shipping.Amount.Value
+		subtotal -= parseMinor(details.Shipping, decimals)
+	}
+
+	details.SubTotal = formatDecimal(subtotal, decimals)
+
+	return Amount{Currency: a.CurrencyCode, Total: a.Value, Details: details}, nil
+}
+
+// fail records the first error found while building the invoice
+func (b *InvoiceBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// discountAmount returns the amount of the percentage or fixed discount applied to the given amount
+func discountAmount(discount *InvoiceDiscount, amount int64, decimals int) (int64, error) {
+	if discount == nil {
+		return 0, nil
+	}
+
+	if discount.Percent != "" {
+		percent, err := parseDecimal(discount.Percent, percentScale)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return roundDiv(amount*percent, 100*pow10(percentScale)), nil
+	}
+
+	if discount.Amount == nil {
+		return 0, fmt.Errorf("discount without percent or amount")
+	}
+
+	return parseDecimal(discount.Amount.Value, decimals)
+}
+
+// discountShares shares the invoice discount among the items in proportion to their discounted
+// amounts. The rounding remainder goes to the last item with an amount, so the shares add up to
+// the invoice discount
+func discountShares(discount int64, nets []int64, netTotal int64) []int64 {
+	shares := make([]int64, len(nets))
+
+	if netTotal == 0 {
+		return shares
+	}
+
+	last := -1
+	shared := int64(0)
+
+	for i, net := range nets {
+		if net == 0 {
+			continue
+		}
+
+		shares[i] = roundDiv(discount*net, netTotal)
+		shared += shares[i]
+		last = i
+	}
+
+	if last >= 0 {
+		shares[last] += discount - shared
+	}
+
+	return shares
+}
+
+// taxAmount returns a copy of the tax with the amount applied to the given amount set
+func taxAmount(tax *InvoiceTax, amount int64, currency string, decimals int) (*InvoiceTax, error) {
+	percent, err := parseDecimal(tax.Percent, percentScale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t := *tax
+
+	t.Amount = &Money{
+		CurrencyCode: currency,
+		Value:        formatDecimal(roundDiv(amount*percent, 100*pow10(percentScale)), decimals),
+	}
+
+	return &t, nil
+}
+
+// taxPercent returns the tax percentage of the given per unit tax over the price. An empty
+// percentage is returned for zero taxes
+func taxPercent(price, tax string, decimals int) (string, error) {
+	p, err := parseDecimal(price, decimals)
+
+	if err != nil {
+		return "", fmt.Errorf("invalid price: %v", err)
+	}
+
+	t, err := parseDecimal(tax, decimals)
+
+	if err != nil {
+		return "", fmt.Errorf("invalid tax: %v", err)
+	}
+
+	if t == 0 {
+		return "", nil
+	}
+
+	scaled := t * 100 * pow10(percentScale)
+
+	if p == 0 || scaled%p != 0 {
+		return "", fmt.Errorf("tax %v is not an exact percentage of price %v", tax, price)
+	}
+
+	percent := formatDecimal(scaled/p, percentScale)
+
+	// Drop the trailing zeros of whole percentages
+	return strings.TrimSuffix(strings.TrimRight(percent, "0"), "."), nil
+}
+
+// currencyDecimals returns the number of decimals of the amounts in the given currency
+func currencyDecimals(currency string) int {
+	switch currency {
+	case "HUF", "JPY", "TWD":
+		return 0
+	}
+
+	return 2
+}
+
+// parseDecimal parses the decimal string as an integer scaled by 10^scale. Values with more
+// decimals than the scale are rejected
+func parseDecimal(s string, scale int) (int64, error) {
+	value := strings.TrimSpace(s)
+	negative := strings.HasPrefix(value, "-")
+
+	value = strings.TrimPrefix(value, "-")
+	parts := strings.SplitN(value, ".", 2)
+
+	if parts[0] == "" {
+		return 0, fmt.Errorf("malformed decimal %q", s)
+	}
+
+	fraction := ""
+
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+
+	if len(fraction) > scale {
+		return 0, fmt.Errorf("decimal %q has more than %v decimals", s, scale)
+	}
+
+	// Pad the fraction up to the scale
+	digits := parts[0] + fraction + strings.Repeat("0", scale-len(fraction))
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("malformed decimal %q", s)
+		}
+	}
+
+	v, err := strconv.ParseInt(digits, 10, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("malformed decimal %q", s)
+	}
+
+	if negative {
+		v = -v
+	}
+
+	return v, nil
+}
+
+// parseMinor parses an amount already validated by the builder
+func parseMinor(s string, decimals int) int64 {
+	v, _ := parseDecimal(s, decimals)
+	return v
+}
+
+// formatDecimal formats the integer scaled by 10^scale as a decimal string
+func formatDecimal(v int64, scale int) string {
+	sign := ""
+
+	if v < 0 {
+		sign, v = "-", -v
+	}
+
+	s := strconv.FormatInt(v, 10)
+
+	if scale == 0 {
+		return sign + s
+	}
+
+	// Pad with leading zeros so there is an integer part
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+
+	return sign + s[:len(s)-scale] + "." + s[len(s)-scale:]
+}
+
+// roundDiv divides rounding half away from zero
+func roundDiv(a, b int64) int64 {
+	if (a < 0) != (b < 0) {
+		return -((-a + b/2) / b)
+	}
+
+	return (a + b/2) / b
+}
+
+// pow10 returns 10^n
+func pow10(n int) int64 {
+	v := int64(1)
+
+	for i := 0; i < n; i++ {
+		v *= 10
+	}
+
+	return v
+}
@@ -0,0 +1,236 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInvoiceBuilder_Build(t *testing.T) {
+	builder := NewInvoiceBuilder("USD").
+		Number("INV-1").
+		Recipient(BillingInfo{EmailAddress: "customer@example.com"}).
+		AddItem(InvoiceItem{
+			Name:       "Yoga mat",
+			Quantity:   "2",
+			UnitAmount: Money{Value: "50.00"},
+			Tax:        &InvoiceTax{Name: "Sales tax", Percent: "7.25"},
+			Discount:   &InvoiceDiscount{Percent: "10"},
+		}).
+		AddItem(InvoiceItem{
+			Name:       "Consulting",
+			Quantity:   "1.5",
+			UnitAmount: Money{Value: "33.33"},
+			Tax:        &InvoiceTax{Name: "Sales tax", Percent: "10"},
+		}).
+		Discount("5").
+		Shipping("10.00", &InvoiceTax{Name: "Shipping tax", Percent: "5"})
+
+	invoice, err := builder.Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	breakdown := invoice.Amount.Breakdown
+
+	// Item amounts are rounded half up, 1.5 x 33.33 = 49.995
+	if breakdown.ItemTotal.Value != "150.00" || breakdown.Discount.ItemDiscount.Value != "10.00" {
+		t.Errorf("Unexpected item total %v and discount %v", breakdown.ItemTotal.Value, breakdown.Discount.ItemDiscount.Value)
+		t.FailNow()
+	}
+
+	// Taxes apply after discounts by default, sharing the invoice discount among the items
+	if invoice.Items[0].Tax.Amount.Value != "6.20" || invoice.Items[1].Tax.Amount.Value != "4.75" || invoice.Amount.Value != "154.45" {
+		t.Errorf("Unexpected taxes after discount %+v %+v %v", invoice.Items[0].Tax, invoice.Items[1].Tax, invoice.Amount.Value)
+		t.FailNow()
+	}
+
+	// The builder items are left untouched
+	if builder.invoice.Items[0].Tax.Amount != nil {
+		t.Errorf("Builder item tax modified")
+		t.FailNow()
+	}
+
+	amount, err := invoice.Amount.PaymentAmount()
+
+	if err != nil {
+		t.Errorf("Cannot get payment amount: %v", err)
+		t.FailNow()
+	}
+
+	if amount.Total != "154.45" || amount.Details.SubTotal != "133.00" || amount.Details.Tax != "11.45" || amount.Details.Shipping != "10.00" {
+		t.Errorf("Unexpected payment amount %+v", amount)
+		t.FailNow()
+	}
+
+	// Taxes can apply before discounts
+	invoice, err = builder.TaxCalculatedBeforeDiscount().Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	breakdown = invoice.Amount.Breakdown
+
+	if invoice.Items[0].Tax.Amount.Value != "7.25" || breakdown.Shipping.Tax.Amount.Value != "0.50" || breakdown.TaxTotal.Value != "12.75" {
+		t.Errorf("Unexpected taxes %+v %+v", invoice.Items[0].Tax, breakdown)
+		t.FailNow()
+	}
+
+	if invoice.Amount.Value != "155.75" {
+		t.Errorf("Unexpected invoice total. Got %v expected %v", invoice.Amount.Value, "155.75")
+		t.FailNow()
+	}
+}
+
+func TestInvoiceBuilder_DiscountShares(t *testing.T) {
+	builder := NewInvoiceBuilder("USD").DiscountAmount("10.00")
+
+	for i := 0; i < 3; i++ {
+		builder.AddItem(InvoiceItem{Name: "Mug", Quantity: "1", UnitAmount: Money{Value: "10.00"}, Tax: &InvoiceTax{Percent: "50"}})
+	}
+
+	invoice, err := builder.Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	// The discount is shared as 3.33, 3.33 and 3.34, so the last item is taxed on 6.66
+	taxes := []string{}
+
+	for _, item := range invoice.Items {
+		taxes = append(taxes, item.Tax.Amount.Value)
+	}
+
+	if taxes[0] != "3.34" || taxes[1] != "3.34" || taxes[2] != "3.33" {
+		t.Errorf("Unexpected item taxes %v", taxes)
+		t.FailNow()
+	}
+
+	shares := discountShares(1000, []int64{1000, 0, 1000, 1000}, 3000)
+
+	if shares[0] != 333 || shares[1] != 0 || shares[3] != 334 || shares[0]+shares[2]+shares[3] != 1000 {
+		t.Errorf("Unexpected discount shares %v", shares)
+		t.FailNow()
+	}
+}
+
+func TestInvoiceBuilder_Configuration(t *testing.T) {
+	builder := NewInvoiceBuilder("USD").
+		AddItem(InvoiceItem{Name: "Mug", Quantity: "1", UnitAmount: Money{Value: "10.00"}, Tax: &InvoiceTax{Percent: "10"}}).
+		Discount("10")
+
+	// The PayPal default of calculating taxes after discounts is not sent
+	invoice, err := builder.Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	if invoice.Configuration != nil {
+		t.Errorf("Unexpected invoice configuration %+v", invoice.Configuration)
+		t.FailNow()
+	}
+
+	// Opting out is sent
+	invoice, err = builder.TaxCalculatedBeforeDiscount().Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	b, err := json.Marshal(invoice.Configuration)
+
+	if err != nil || string(b) != `{"tax_calculated_after_discount":false}` {
+		t.Errorf("Unexpected invoice configuration. Got %s expected %v", b, `{"tax_calculated_after_discount":false}`)
+		t.FailNow()
+	}
+}
+
+func TestInvoiceBuilder_AddItemList(t *testing.T) {
+	invoice, err := NewInvoiceBuilder("USD").
+		AddItemList(ItemList{Items: []Item{
+			{Name: "Mug", Quantity: 3, Price: "10.00", Tax: "0.80", Currency: "USD"},
+			{Name: "Sticker", Quantity: 1, Price: "1.50"},
+		}}).
+		Custom("Packaging", "-0.50").
+		Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	if invoice.Items[0].Tax.Percent != "8" || invoice.Items[1].Tax != nil || invoice.Items[1].UnitAmount.CurrencyCode != "USD" {
+		t.Errorf("Unexpected invoice items %+v", invoice.Items)
+		t.FailNow()
+	}
+
+	if invoice.Amount.Breakdown.TaxTotal.Value != "2.40" || invoice.Amount.Value != "33.40" {
+		t.Errorf("Unexpected invoice amount %+v", invoice.Amount)
+		t.FailNow()
+	}
+
+	// Taxes that are not an exact percentage cannot be converted
+	_, err = NewInvoiceBuilder("USD").
+		AddItemList(ItemList{Items: []Item{{Name: "Pen", Quantity: 1, Price: "3.00", Tax: "0.10"}}}).
+		Build()
+
+	if err == nil {
+		t.Errorf("Expected an error converting an inexact tax")
+		t.FailNow()
+	}
+}
+
+func TestInvoiceBuilder_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *InvoiceBuilder
+	}{
+		{"no items", NewInvoiceBuilder("USD")},
+		{"currency mismatch", NewInvoiceBuilder("USD").AddItem(InvoiceItem{Name: "A", Quantity: "1", UnitAmount: Money{CurrencyCode: "EUR", Value: "1.00"}})},
+		{"too many decimals", NewInvoiceBuilder("JPY").AddItem(InvoiceItem{Name: "A", Quantity: "1", UnitAmount: Money{Value: "1.50"}})},
+		{"malformed quantity", NewInvoiceBuilder("USD").AddItem(InvoiceItem{Name: "A", Quantity: "one", UnitAmount: Money{Value: "1.00"}})},
+		{"negative total", NewInvoiceBuilder("USD").AddItem(InvoiceItem{Name: "A", Quantity: "1", UnitAmount: Money{Value: "1.00"}}).DiscountAmount("2.00")},
+	}
+
+	for _, test := range tests {
+		if _, err := test.builder.Build(); err == nil {
+			t.Errorf("Expected an error building an invoice with %v", test.name)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		value     string
+		scale     int
+		expected  int64
+		formatted string
+	}{
+		{"10.00", 2, 1000, "10.00"},
+		{"0.5", 2, 50, "0.50"},
+		{"-0.05", 2, -5, "-0.05"},
+		{"1500", 0, 1500, "1500"},
+		{"1.5", 5, 150000, "1.50000"},
+	}
+
+	for _, test := range tests {
+		v, err := parseDecimal(test.value, test.scale)
+
+		if err != nil || v != test.expected {
+			t.Errorf("Unexpected parsed %v. Got %v (%v) expected %v", test.value, v, err, test.expected)
+			continue
+		}
+
+		if formatDecimal(v, test.scale) != test.formatted {
+			t.Errorf("Unexpected formatted %v. Got %v expected %v", test.value, formatDecimal(v, test.scale), test.formatted)
+		}
+	}
+}
@@ -0,0 +1,687 @@
+package gopaypal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	InvoiceStatusDraft             = "DRAFT"
+	InvoiceStatusSent              = "SENT"
+	InvoiceStatusScheduled         = "SCHEDULED"
+	InvoiceStatusPaymentPending    = "PAYMENT_PENDING"
+	InvoiceStatusPaid              = "PAID"
+	InvoiceStatusMarkedAsPaid      = "MARKED_AS_PAID"
+	InvoiceStatusPartiallyPaid     = "PARTIALLY_PAID"
+	InvoiceStatusCancelled         = "CANCELLED"
+	InvoiceStatusRefunded          = "REFUNDED"
+	InvoiceStatusPartiallyRefunded = "PARTIALLY_REFUNDED"
+	InvoiceStatusMarkedAsRefunded  = "MARKED_AS_REFUNDED"
+	InvoiceStatusUnpaid            = "UNPAID"
+
+	PaymentTermDueOnReceipt       = "DUE_ON_RECEIPT"
+	PaymentTermDueOnDateSpecified = "DUE_ON_DATE_SPECIFIED"
+	PaymentTermNet10              = "NET_10"
+	PaymentTermNet15              = "NET_15"
+	PaymentTermNet30              = "NET_30"
+	PaymentTermNet45              = "NET_45"
+	PaymentTermNet60              = "NET_60"
+	PaymentTermNet90              = "NET_90"
+	PaymentTermNoDueDate          = "NO_DUE_DATE"
+
+	InvoicePaymentMethodBankTransfer = "BANK_TRANSFER"
+	InvoicePaymentMethodCash         = "CASH"
+	InvoicePaymentMethodCheck        = "CHECK"
+	InvoicePaymentMethodCreditCard   = "CREDIT_CARD"
+	InvoicePaymentMethodDebitCard    = "DEBIT_CARD"
+	InvoicePaymentMethodPayPal       = "PAYPAL"
+	InvoicePaymentMethodWireTransfer = "WIRE_TRANSFER"
+	InvoicePaymentMethodOther        = "OTHER"
+)
+
+// Invoice is a v2 invoice. Drafts are created with CreateInvoice, usually from an InvoiceBuilder,
+// and sent to the recipients with SendInvoice
+type Invoice struct {
+	ID                   string                `json:"id,omitempty"`
+	ParentID             string                `json:"parent_id,omitempty"`
+	Status               string                `json:"status,omitempty"`
+	Detail               *InvoiceDetail        `json:"detail,omitempty"`
+	Invoicer             *Invoicer             `json:"invoicer,omitempty"`
+	PrimaryRecipients    []RecipientInfo       `json:"primary_recipients,omitempty"`
+	AdditionalRecipients []EmailRecipient      `json:"additional_recipients,omitempty"`
+	Items                []InvoiceItem         `json:"items,omitempty"`
+	Configuration        *InvoiceConfiguration `json:"configuration,omitempty"`
+	Amount               *InvoiceAmount        `json:"amount,omitempty"`
+	DueAmount            *Money                `json:"due_amount,omitempty"`
+	Gratuity             *Money                `json:"gratuity,omitempty"`
+	Payments             *InvoicePayments      `json:"payments,omitempty"`
+	Refunds              *InvoiceRefunds       `json:"refunds,omitempty"`
+	Links                Links                 `json:"links,omitempty"`
+	RequestID            string                `json:"-"`
+}
+
+// InvoiceDetail holds the number, dates, currency and notes of an invoice
+type InvoiceDetail struct {
+	InvoiceNumber      string           `json:"invoice_number,omitempty"`
+	Reference          string           `json:"reference,omitempty"`
+	InvoiceDate        string           `json:"invoice_date,omitempty"`
+	CurrencyCode       string           `json:"currency_code"`
+	Note               string           `json:"note,omitempty"`
+	TermsAndConditions string           `json:"terms_and_conditions,omitempty"`
+	Memo               string           `json:"memo,omitempty"`
+	PaymentTerm        *PaymentTerm     `json:"payment_term,omitempty"`
+	Metadata           *InvoiceMetadata `json:"metadata,omitempty"`
+}
+
+// PaymentTerm is the payment due date of an invoice. DueDate is only used with the
+// DUE_ON_DATE_SPECIFIED term
+type PaymentTerm struct {
+	TermType string `json:"term_type,omitempty"`
+	DueDate  string `json:"due_date,omitempty"`
+}
+
+// InvoiceMetadata holds the audit details of an invoice set by PayPal
+type InvoiceMetadata struct {
+	CreateTime       string `json:"create_time,omitempty"`
+	CreatedBy        string `json:"created_by,omitempty"`
+	LastUpdateTime   string `json:"last_update_time,omitempty"`
+	LastUpdatedBy    string `json:"last_updated_by,omitempty"`
+	CancelTime       string `json:"cancel_time,omitempty"`
+	FirstSentTime    string `json:"first_sent_time,omitempty"`
+	LastSentTime     string `json:"last_sent_time,omitempty"`
+	RecipientViewURL string `json:"recipient_view_url,omitempty"`
+	InvoicerViewURL  string `json:"invoicer_view_url,omitempty"`
+}
+
+// Invoicer is the merchant issuing an invoice
+type Invoicer struct {
+	Name            *Name            `json:"name,omitempty"`
+	BusinessName    string           `json:"business_name,omitempty"`
+	EmailAddress    string           `json:"email_address,omitempty"`
+	Phones          []InvoicePhone   `json:"phones,omitempty"`
+	Address         *AddressPortable `json:"address,omitempty"`
+	Website         string           `json:"website,omitempty"`
+	TaxID           string           `json:"tax_id,omitempty"`
+	LogoURL         string           `json:"logo_url,omitempty"`
+	AdditionalNotes string           `json:"additional_notes,omitempty"`
+}
+
+// InvoicePhone is a phone number of an invoicer or a recipient
+type InvoicePhone struct {
+	CountryCode     string `json:"country_code"`
+	NationalNumber  string `json:"national_number"`
+	ExtensionNumber string `json:"extension_number,omitempty"`
+	PhoneType       string `json:"phone_type,omitempty"`
+}
+
+// RecipientInfo holds the billing and shipping details of an invoice recipient
+type RecipientInfo struct {
+	BillingInfo  *BillingInfo `json:"billing_info,omitempty"`
+	ShippingInfo *ContactInfo `json:"shipping_info,omitempty"`
+}
+
+// BillingInfo is the billing contact of an invoice recipient
+type BillingInfo struct {
+	Name           *Name            `json:"name,omitempty"`
+	BusinessName   string           `json:"business_name,omitempty"`
+	EmailAddress   string           `json:"email_address,omitempty"`
+	Phones         []InvoicePhone   `json:"phones,omitempty"`
+	Address        *AddressPortable `json:"address,omitempty"`
+	AdditionalInfo string           `json:"additional_info,omitempty"`
+	Language       string           `json:"language,omitempty"`
+}
+
+// ContactInfo is the shipping contact of an invoice recipient
+type ContactInfo struct {
+	Name         *Name            `json:"name,omitempty"`
+	BusinessName string           `json:"business_name,omitempty"`
+	Address      *AddressPortable `json:"address,omitempty"`
+}
+
+// EmailRecipient is an email address receiving a copy of an invoice
+type EmailRecipient struct {
+	EmailAddress string `json:"email_address"`
+}
+
+// InvoiceItem is a line of an invoice. Quantity may be fractional, e.g. hours of work
+type InvoiceItem struct {
+	ID            string           `json:"id,omitempty"`
+	Name          string           `json:"name"`
+	Description   string           `json:"description,omitempty"`
+	Quantity      string           `json:"quantity"`
+	UnitAmount    Money            `json:"unit_amount"`
+	Tax           *InvoiceTax      `json:"tax,omitempty"`
+	Discount      *InvoiceDiscount `json:"discount,omitempty"`
+	UnitOfMeasure string           `json:"unit_of_measure,omitempty"`
+	ItemDate      string           `json:"item_date,omitempty"`
+}
+
+// InvoiceTax is a tax applied as a percentage. The amount is calculated by PayPal
+type InvoiceTax struct {
+	Name    string `json:"name"`
+	Percent string `json:"percent"`
+	Amount  *Money `json:"amount,omitempty"`
+}
+
+// InvoiceDiscount is a discount given either as a percentage or as a fixed amount
+type InvoiceDiscount struct {
+	Percent string `json:"percent,omitempty"`
+	Amount  *Money `json:"amount,omitempty"`
+}
+
+// InvoiceConfiguration holds the tax, tip and partial payment settings of an invoice. A nil
+// TaxCalculatedAfterDiscount leaves the PayPal default, which calculates taxes after discounts
+type InvoiceConfiguration struct {
+	TaxCalculatedAfterDiscount *bool           `json:"tax_calculated_after_discount,omitempty"`
+	TaxInclusive               bool            `json:"tax_inclusive,omitempty"`
+	AllowTip                   bool            `json:"allow_tip,omitempty"`
+	PartialPayment             *PartialPayment `json:"partial_payment,omitempty"`
+	TemplateID                 string          `json:"template_id,omitempty"`
+}
+
+// PartialPayment allows the recipient to pay an invoice in several payments
+type PartialPayment struct {
+	AllowPartialPayment bool   `json:"allow_partial_payment"`
+	MinimumAmountDue    *Money `json:"minimum_amount_due,omitempty"`
+}
+
+// InvoiceAmount is the total of an invoice along with its breakdown
+type InvoiceAmount struct {
+	CurrencyCode string                  `json:"currency_code"`
+	Value        string                  `json:"value"`
+	Breakdown    *InvoiceAmountBreakdown `json:"breakdown,omitempty"`
+}
+
+// InvoiceAmountBreakdown holds the item total, discounts, taxes, shipping and custom amount of an invoice
+type InvoiceAmountBreakdown struct {
+	ItemTotal *Money            `json:"item_total,omitempty"`
+	Discount  *InvoiceDiscounts `json:"discount,omitempty"`
+	TaxTotal  *Money            `json:"tax_total,omitempty"`
+	Shipping  *InvoiceShipping  `json:"shipping,omitempty"`
+	Custom    *CustomAmount     `json:"custom,omitempty"`
+}
+
+// InvoiceDiscounts holds the invoice level discount and the total of the item discounts
+type InvoiceDiscounts struct {
+	InvoiceDiscount *InvoiceDiscount `json:"invoice_discount,omitempty"`
+	ItemDiscount    *Money           `json:"item_discount,omitempty"`
+}
+
+// InvoiceShipping is the shipping cost of an invoice along with its tax
+type InvoiceShipping struct {
+	Amount *Money      `json:"amount,omitempty"`
+	Tax    *InvoiceTax `json:"tax,omitempty"`
+}
+
+// CustomAmount is a labelled amount added to, or subtracted from when negative, an invoice total
+type CustomAmount struct {
+	Label  string `json:"label"`
+	Amount *Money `json:"amount,omitempty"`
+}
+
+// InvoicePayments holds the payments made to an invoice
+type InvoicePayments struct {
+	PaidAmount   *Money                 `json:"paid_amount,omitempty"`
+	Transactions []InvoicePaymentDetail `json:"transactions,omitempty"`
+}
+
+// InvoicePaymentDetail is a payment of an invoice. Payments made outside PayPal are recorded with
+// RecordInvoicePayment
+type InvoicePaymentDetail struct {
+	Type         string       `json:"type,omitempty"`
+	PaymentID    string       `json:"payment_id,omitempty"`
+	PaymentDate  string       `json:"payment_date,omitempty"`
+	Method       string       `json:"method"`
+	Note         string       `json:"note,omitempty"`
+	Amount       *Money       `json:"amount,omitempty"`
+	ShippingInfo *ContactInfo `json:"shipping_info,omitempty"`
+}
+
+// InvoiceRefunds holds the refunds of an invoice
+type InvoiceRefunds struct {
+	RefundAmount *Money                `json:"refund_amount,omitempty"`
+	Transactions []InvoiceRefundDetail `json:"transactions,omitempty"`
+}
+
+// InvoiceRefundDetail is a refund of an invoice. Refunds made outside PayPal are recorded with
+// RecordInvoiceRefund
+type InvoiceRefundDetail struct {
+	Type       string `json:"type,omitempty"`
+	RefundID   string `json:"refund_id,omitempty"`
+	RefundDate string `json:"refund_date,omitempty"`
+	Method     string `json:"method"`
+	Amount     *Money `json:"amount,omitempty"`
+}
+
+// InvoiceNotification holds the email sent when an invoice is sent, reminded or cancelled. Unlike
+// the PayPal defaults, the recipient and the invoicer are only notified when asked to
+type InvoiceNotification struct {
+	Subject              string   `json:"subject,omitempty"`
+	Note                 string   `json:"note,omitempty"`
+	SendToInvoicer       bool     `json:"send_to_invoicer"`
+	SendToRecipient      bool     `json:"send_to_recipient"`
+	AdditionalRecipients []string `json:"additional_recipients,omitempty"`
+}
+
+// InvoiceListParams paginates the invoices returned by ListInvoices and SearchInvoices. Zero values
+// are not sent
+type InvoiceListParams struct {
+	Page          int
+	PageSize      int
+	TotalRequired bool
+	Fields        string
+}
+
+// InvoiceList is a page of invoices
+type InvoiceList struct {
+	Items      []Invoice `json:"items"`
+	TotalItems int       `json:"total_items,omitempty"`
+	TotalPages int       `json:"total_pages,omitempty"`
+	Links      Links     `json:"links,omitempty"`
+}
+
+// InvoiceSearch holds the criteria of SearchInvoices. Zero values are not sent
+type InvoiceSearch struct {
+	RecipientEmail        string       `json:"recipient_email,omitempty"`
+	RecipientFirstName    string       `json:"recipient_first_name,omitempty"`
+	RecipientLastName     string       `json:"recipient_last_name,omitempty"`
+	RecipientBusinessName string       `json:"recipient_business_name,omitempty"`
+	InvoiceNumber         string       `json:"invoice_number,omitempty"`
+	Status                []string     `json:"status,omitempty"`
+	Reference             string       `json:"reference,omitempty"`
+	CurrencyCode          string       `json:"currency_code,omitempty"`
+	Memo                  string       `json:"memo,omitempty"`
+	TotalAmountRange      *AmountRange `json:"total_amount_range,omitempty"`
+	InvoiceDateRange      *DateRange   `json:"invoice_date_range,omitempty"`
+	DueDateRange          *DateRange   `json:"due_date_range,omitempty"`
+	PaymentDateRange      *DateRange   `json:"payment_date_range,omitempty"`
+	CreationDateRange     *DateRange   `json:"creation_date_range,omitempty"`
+	Archived              *bool        `json:"archived,omitempty"`
+	Fields                []string     `json:"fields,omitempty"`
+}
+
+// AmountRange is an inclusive range of amounts
+type AmountRange struct {
+	LowerAmount Money `json:"lower_amount"`
+	UpperAmount Money `json:"upper_amount"`
+}
+
+// DateRange is an inclusive range of dates or date times
+type DateRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// InvoiceQRCodeRequest holds the size of a generated QR code and the page it opens, either pay or
+// details. Zero values use the PayPal defaults
+type InvoiceQRCodeRequest struct {
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+// InvoiceTemplate is a template invoices can be created from
+type InvoiceTemplate struct {
+	ID              string        `json:"id,omitempty"`
+	Name            string        `json:"name"`
+	DefaultTemplate bool          `json:"default_template,omitempty"`
+	TemplateInfo    *TemplateInfo `json:"template_info,omitempty"`
+	UnitOfMeasure   string        `json:"unit_of_measure,omitempty"`
+	Standard        bool          `json:"standard_template,omitempty"`
+	Links           Links         `json:"links,omitempty"`
+	RequestID       string        `json:"-"`
+}
+
+// TemplateInfo holds the invoice details filled in by a template
+type TemplateInfo struct {
+	Detail               *InvoiceDetail        `json:"detail,omitempty"`
+	Invoicer             *Invoicer             `json:"invoicer,omitempty"`
+	PrimaryRecipients    []RecipientInfo       `json:"primary_recipients,omitempty"`
+	AdditionalRecipients []EmailRecipient      `json:"additional_recipients,omitempty"`
+	Items                []InvoiceItem         `json:"items,omitempty"`
+	Configuration        *InvoiceConfiguration `json:"configuration,omitempty"`
+	Amount               *InvoiceAmount        `json:"amount,omitempty"`
+	DueAmount            *Money                `json:"due_amount,omitempty"`
+}
+
+// Query returns the list parameters encoded as an URL query
+func (p InvoiceListParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	if p.TotalRequired {
+		query.Set("total_required", "true")
+	}
+
+	if p.Fields != "" {
+		query.Set("fields", p.Fields)
+	}
+
+	return query
+}
+
+// LinkByRel returns the invoice link with the given relation
+func (i *Invoice) LinkByRel(rel string) (Link, bool) {
+	return i.Links.ByRel(rel)
+}
+
+// CreateInvoice creates a draft invoice
+func (c Client) CreateInvoice(invoice Invoice) (*Invoice, error) {
+	return c.CreateInvoiceWithContext(context.Background(), invoice)
+}
+
+// CreateInvoiceWithContext creates a draft invoice using the given context
+func (c Client) CreateInvoiceWithContext(ctx context.Context, invoice Invoice) (*Invoice, error) {
+	// Hold invoice response
+	d := Invoice{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, InvoicesURL, &invoice, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// GetInvoice gets the details of the invoice with the given ID
+func (c Client) GetInvoice(invoiceID string) (*Invoice, error) {
+	return c.GetInvoiceWithContext(context.Background(), invoiceID)
+}
+
+// GetInvoiceWithContext gets the details of the invoice with the given ID using the given context
+func (c Client) GetInvoiceWithContext(ctx context.Context, invoiceID string) (*Invoice, error) {
+	// Hold invoice response
+	d := Invoice{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(InvoiceURL, invoiceID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// DeleteInvoice deletes the draft or scheduled invoice with the given ID
+func (c Client) DeleteInvoice(invoiceID string) error {
+	return c.DeleteInvoiceWithContext(context.Background(), invoiceID)
+}
+
+// DeleteInvoiceWithContext deletes the draft or scheduled invoice with the given ID using the given context
+func (c Client) DeleteInvoiceWithContext(ctx context.Context, invoiceID string) error {
+	_, err := c.JSONRequest(ctx, http.MethodDelete, fmt.Sprintf(InvoiceURL, invoiceID), nil, nil)
+
+	return err
+}
+
+// SendInvoice sends the draft invoice with the given ID. The payer view link is returned when the
+// invoice is not emailed to the recipient, nil otherwise
+func (c Client) SendInvoice(invoiceID string, notification InvoiceNotification) (*Link, error) {
+	return c.SendInvoiceWithContext(context.Background(), invoiceID, notification)
+}
+
+// SendInvoiceWithContext sends the draft invoice with the given ID using the given context
+func (c Client) SendInvoiceWithContext(ctx context.Context, invoiceID string, notification InvoiceNotification) (*Link, error) {
+	// Hold payer view link response
+	d := Link{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(InvoiceSendURL, invoiceID), &notification, &d); err != nil {
+		return nil, err
+	}
+
+	if d.Href == "" {
+		return nil, nil
+	}
+
+	return &d, nil
+}
+
+// RemindInvoice sends a reminder of the invoice with the given ID
+func (c Client) RemindInvoice(invoiceID string, notification InvoiceNotification) error {
+	return c.RemindInvoiceWithContext(context.Background(), invoiceID, notification)
+}
+
+// RemindInvoiceWithContext sends a reminder of the invoice with the given ID using the given context
+func (c Client) RemindInvoiceWithContext(ctx context.Context, invoiceID string, notification InvoiceNotification) error {
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(InvoiceRemindURL, invoiceID), &notification, nil)
+
+	return err
+}
+
+// CancelInvoice cancels the sent invoice with the given ID
+func (c Client) CancelInvoice(invoiceID string, notification InvoiceNotification) error {
+	return c.CancelInvoiceWithContext(context.Background(), invoiceID, notification)
+}
+
+// CancelInvoiceWithContext cancels the sent invoice with the given ID using the given context
+func (c Client) CancelInvoiceWithContext(ctx context.Context, invoiceID string, notification InvoiceNotification) error {
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(InvoiceCancelURL, invoiceID), &notification, nil)
+
+	return err
+}
+
+// RecordInvoicePayment records a payment of the invoice with the given ID made outside PayPal, e.g.
+// in cash. It returns the ID of the recorded payment
+func (c Client) RecordInvoicePayment(invoiceID string, payment InvoicePaymentDetail) (string, error) {
+	return c.RecordInvoicePaymentWithContext(context.Background(), invoiceID, payment)
+}
+
+// RecordInvoicePaymentWithContext records a payment of the invoice with the given ID made outside
+// PayPal using the given context
+func (c Client) RecordInvoicePaymentWithContext(ctx context.Context, invoiceID string, payment InvoicePaymentDetail) (string, error) {
+	// Hold recorded payment response
+	d := struct {
+		PaymentID string `json:"payment_id"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(InvoicePaymentsURL, invoiceID), &payment, &d); err != nil {
+		return "", err
+	}
+
+	return d.PaymentID, nil
+}
+
+// RecordInvoiceRefund records a refund of the invoice with the given ID made outside PayPal. It
+// returns the ID of the recorded refund
+func (c Client) RecordInvoiceRefund(invoiceID string, refund InvoiceRefundDetail) (string, error) {
+	return c.RecordInvoiceRefundWithContext(context.Background(), invoiceID, refund)
+}
+
+// RecordInvoiceRefundWithContext records a refund of the invoice with the given ID made outside
+// PayPal using the given context
+func (c Client) RecordInvoiceRefundWithContext(ctx context.Context, invoiceID string, refund InvoiceRefundDetail) (string, error) {
+	// Hold recorded refund response
+	d := struct {
+		RefundID string `json:"refund_id"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(InvoiceRefundsURL, invoiceID), &refund, &d); err != nil {
+		return "", err
+	}
+
+	return d.RefundID, nil
+}
+
+// ListInvoices gets a page of the merchant invoices
+func (c Client) ListInvoices(params InvoiceListParams) (*InvoiceList, error) {
+	return c.ListInvoicesWithContext(context.Background(), params)
+}
+
+// ListInvoicesWithContext gets a page of the merchant invoices using the given context
+func (c Client) ListInvoicesWithContext(ctx context.Context, params InvoiceListParams) (*InvoiceList, error) {
+	return c.invoiceList(ctx, http.MethodGet, InvoicesURL, params, nil)
+}
+
+// SearchInvoices gets a page of the merchant invoices matching the given search criteria
+func (c Client) SearchInvoices(search InvoiceSearch, params InvoiceListParams) (*InvoiceList, error) {
+	return c.SearchInvoicesWithContext(context.Background(), search, params)
+}
+
+// SearchInvoicesWithContext gets a page of the merchant invoices matching the given search criteria
+// using the given context
+func (c Client) SearchInvoicesWithContext(ctx context.Context, search InvoiceSearch, params InvoiceListParams) (*InvoiceList, error) {
+	return c.invoiceList(ctx, http.MethodPost, InvoiceSearchURL, params, &search)
+}
+
+// GenerateNextInvoiceNumber gets the next invoice number following the last one used by the merchant
+func (c Client) GenerateNextInvoiceNumber() (string, error) {
+	return c.GenerateNextInvoiceNumberWithContext(context.Background())
+}
+
+// GenerateNextInvoiceNumberWithContext gets the next invoice number using the given context
+func (c Client) GenerateNextInvoiceNumberWithContext(ctx context.Context) (string, error) {
+	// Hold invoice number response
+	d := struct {
+		InvoiceNumber string `json:"invoice_number"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodPost, InvoiceNextNumberURL, nil, &d); err != nil {
+		return "", err
+	}
+
+	return d.InvoiceNumber, nil
+}
+
+// GenerateInvoiceQRCode generates a QR code of the sent invoice with the given ID, which recipients
+// scan to pay or view it. It returns the PNG image
+func (c Client) GenerateInvoiceQRCode(invoiceID string, req InvoiceQRCodeRequest) ([]byte, error) {
+	return c.GenerateInvoiceQRCodeWithContext(context.Background(), invoiceID, req)
+}
+
+// GenerateInvoiceQRCodeWithContext generates a QR code of the sent invoice with the given ID using
+// the given context
+func (c Client) GenerateInvoiceQRCodeWithContext(ctx context.Context, invoiceID string, req InvoiceQRCodeRequest) ([]byte, error) {
+	b, err := json.Marshal(&req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Create auth request
+	r, err := c.AuthRequestWithContext(ctx, fmt.Sprintf(InvoiceQRCodeURL, invoiceID), b, http.MethodPost)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Set content type
+	r.Header.Set("Content-Type", "application/json")
+
+	// Execute request
+	res, err := c.Execute(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The image is sent base64 encoded
+	return base64.StdEncoding.DecodeString(strings.Trim(strings.TrimSpace(string(res)), `"`))
+}
+
+// CreateInvoiceTemplate creates an invoice template
+func (c Client) CreateInvoiceTemplate(template InvoiceTemplate) (*InvoiceTemplate, error) {
+	return c.CreateInvoiceTemplateWithContext(context.Background(), template)
+}
+
+// CreateInvoiceTemplateWithContext creates an invoice template using the given context
+func (c Client) CreateInvoiceTemplateWithContext(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	return c.invoiceTemplateRequest(ctx, http.MethodPost, InvoiceTemplatesURL, &template)
+}
+
+// ListInvoiceTemplates gets every invoice template of the merchant
+func (c Client) ListInvoiceTemplates() ([]InvoiceTemplate, error) {
+	return c.ListInvoiceTemplatesWithContext(context.Background())
+}
+
+// ListInvoiceTemplatesWithContext gets every invoice template of the merchant using the given context
+func (c Client) ListInvoiceTemplatesWithContext(ctx context.Context) ([]InvoiceTemplate, error) {
+	// Hold template list response
+	d := struct {
+		Templates []InvoiceTemplate `json:"templates"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, InvoiceTemplatesURL+"?fields=all", nil, &d); err != nil {
+		return nil, err
+	}
+
+	return d.Templates, nil
+}
+
+// GetInvoiceTemplate gets the details of the invoice template with the given ID
+func (c Client) GetInvoiceTemplate(templateID string) (*InvoiceTemplate, error) {
+	return c.GetInvoiceTemplateWithContext(context.Background(), templateID)
+}
+
+// GetInvoiceTemplateWithContext gets the details of the invoice template with the given ID using
+// the given context
+func (c Client) GetInvoiceTemplateWithContext(ctx context.Context, templateID string) (*InvoiceTemplate, error) {
+	return c.invoiceTemplateRequest(ctx, http.MethodGet, fmt.Sprintf(InvoiceTemplateURL, templateID), nil)
+}
+
+// UpdateInvoiceTemplate replaces the invoice template with the given ID
+func (c Client) UpdateInvoiceTemplate(templateID string, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	return c.UpdateInvoiceTemplateWithContext(context.Background(), templateID, template)
+}
+
+// UpdateInvoiceTemplateWithContext replaces the invoice template with the given ID using the given context
+func (c Client) UpdateInvoiceTemplateWithContext(ctx context.Context, templateID string, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	return c.invoiceTemplateRequest(ctx, http.MethodPut, fmt.Sprintf(InvoiceTemplateURL, templateID), &template)
+}
+
+// DeleteInvoiceTemplate deletes the invoice template with the given ID
+func (c Client) DeleteInvoiceTemplate(templateID string) error {
+	return c.DeleteInvoiceTemplateWithContext(context.Background(), templateID)
+}
+
+// DeleteInvoiceTemplateWithContext deletes the invoice template with the given ID using the given context
+func (c Client) DeleteInvoiceTemplateWithContext(ctx context.Context, templateID string) error {
+	_, err := c.JSONRequest(ctx, http.MethodDelete, fmt.Sprintf(InvoiceTemplateURL, templateID), nil, nil)
+
+	return err
+}
+
+// invoiceList runs an invoice list or search request with the given list parameters
+func (c Client) invoiceList(ctx context.Context, method, endpoint string, params InvoiceListParams, in interface{}) (*InvoiceList, error) {
+	if query := params.Query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	// Hold invoice list response
+	d := InvoiceList{}
+
+	if _, err := c.JSONRequest(ctx, method, endpoint, in, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// invoiceTemplateRequest runs an invoice template request and returns the resulting template
+func (c Client) invoiceTemplateRequest(ctx context.Context, method, endpoint string, in interface{}) (*InvoiceTemplate, error) {
+	// Hold template response
+	d := InvoiceTemplate{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, in, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
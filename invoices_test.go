@@ -0,0 +1,362 @@
+package gopaypal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_InvoiceLifecycle(t *testing.T) {
+	notifications := map[string]InvoiceNotification{}
+	deleted := false
+
+	notify := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			notification := InvoiceNotification{}
+
+			json.NewDecoder(r.Body).Decode(&notification)
+			notifications[name] = notification
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v2/invoicing/generate-next-invoice-number": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"invoice_number": "0042"}`))
+		},
+		"POST /v2/invoicing/invoices": func(w http.ResponseWriter, r *http.Request) {
+			invoice := Invoice{}
+
+			if err := json.NewDecoder(r.Body).Decode(&invoice); err != nil || invoice.Detail.InvoiceNumber != "0042" || len(invoice.Items) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "INV2-Z56S-5LLA-Q52L-CPZ5", "status": "DRAFT", "detail": {"invoice_number": "0042", "currency_code": "USD"}, "amount": {"currency_code": "USD", "value": "100.00"}}`))
+		},
+		"POST /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5/send": func(w http.ResponseWriter, r *http.Request) {
+			notification := InvoiceNotification{}
+
+			json.NewDecoder(r.Body).Decode(&notification)
+			notifications["send"] = notification
+
+			w.Write([]byte(`{"href": "https://www.sandbox.paypal.com/invoice/p/#Z56S5LLAQ52LCPZ5", "rel": "payer-view", "method": "GET"}`))
+		},
+		"POST /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5/remind": notify("remind"),
+		"POST /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5/cancel": notify("cancel"),
+		"POST /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5/payments": func(w http.ResponseWriter, r *http.Request) {
+			payment := InvoicePaymentDetail{}
+
+			if err := json.NewDecoder(r.Body).Decode(&payment); err != nil || payment.Method != InvoicePaymentMethodCash {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"payment_id": "EXTR-86F38350LX4353815"}`))
+		},
+		"POST /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5/refunds": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"refund_id": "EXTR-2LG703375E477444T"}`))
+		},
+		"DELETE /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5": func(w http.ResponseWriter, r *http.Request) {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	number, err := client.GenerateNextInvoiceNumber()
+
+	if err != nil {
+		t.Errorf("Cannot generate invoice number: %v", err)
+		t.FailNow()
+	}
+
+	draft, err := NewInvoiceBuilder("USD").
+		Number(number).
+		Recipient(BillingInfo{EmailAddress: "customer@example.com"}).
+		AddItem(InvoiceItem{Name: "Yoga mat", Quantity: "2", UnitAmount: Money{Value: "50.00"}}).
+		Build()
+
+	if err != nil {
+		t.Errorf("Cannot build invoice: %v", err)
+		t.FailNow()
+	}
+
+	invoice, err := client.CreateInvoice(*draft)
+
+	if err != nil {
+		t.Errorf("Cannot create invoice: %v", err)
+		t.FailNow()
+	}
+
+	if invoice.Status != InvoiceStatusDraft || invoice.Amount.Value != draft.Amount.Value || invoice.RequestID == "" {
+		t.Errorf("Unexpected created invoice %+v", invoice)
+		t.FailNow()
+	}
+
+	link, err := client.SendInvoice(invoice.ID, InvoiceNotification{Subject: "Your invoice", SendToInvoicer: true})
+
+	if err != nil {
+		t.Errorf("Cannot send invoice: %v", err)
+		t.FailNow()
+	}
+
+	// Recipients are only notified when asked to
+	if link == nil || link.Rel != "payer-view" || notifications["send"].SendToRecipient || !notifications["send"].SendToInvoicer {
+		t.Errorf("Unexpected sent invoice link %+v %+v", link, notifications)
+		t.FailNow()
+	}
+
+	if err := client.RemindInvoice(invoice.ID, InvoiceNotification{Note: "Please pay", SendToRecipient: true}); err != nil {
+		t.Errorf("Cannot remind invoice: %v", err)
+		t.FailNow()
+	}
+
+	// Record payments made outside PayPal
+	paymentID, err := client.RecordInvoicePayment(invoice.ID, InvoicePaymentDetail{
+		Method:      InvoicePaymentMethodCash,
+		PaymentDate: "2018-05-01",
+		Amount:      &Money{CurrencyCode: "USD", Value: "100.00"},
+	})
+
+	if err != nil || paymentID != "EXTR-86F38350LX4353815" {
+		t.Errorf("Cannot record invoice payment %v: %v", paymentID, err)
+		t.FailNow()
+	}
+
+	refundID, err := client.RecordInvoiceRefund(invoice.ID, InvoiceRefundDetail{
+		Method: InvoicePaymentMethodCash,
+		Amount: &Money{CurrencyCode: "USD", Value: "100.00"},
+	})
+
+	if err != nil || refundID != "EXTR-2LG703375E477444T" {
+		t.Errorf("Cannot record invoice refund %v: %v", refundID, err)
+		t.FailNow()
+	}
+
+	if err := client.CancelInvoice(invoice.ID, InvoiceNotification{Note: "Cancelled"}); err != nil {
+		t.Errorf("Cannot cancel invoice: %v", err)
+		t.FailNow()
+	}
+
+	if err := client.DeleteInvoice(invoice.ID); err != nil || !deleted {
+		t.Errorf("Cannot delete invoice: %v", err)
+		t.FailNow()
+	}
+
+	if notifications["remind"].Note != "Please pay" || notifications["cancel"].Note != "Cancelled" {
+		t.Errorf("Unexpected invoice notifications %+v", notifications)
+		t.FailNow()
+	}
+}
+
+func TestClient_GetInvoice(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v2/invoicing/invoices/INV2-Z56S-5LLA-Q52L-CPZ5": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"id": "INV2-Z56S-5LLA-Q52L-CPZ5",
+				"status": "SENT",
+				"detail": {"invoice_number": "0042", "currency_code": "USD"},
+				"items": [{"name": "Yoga mat", "quantity": "1", "unit_amount": {"currency_code": "USD", "value": "100.00"}}],
+				"amount": {"currency_code": "USD", "value": "100.00", "breakdown": {"item_total": {"currency_code": "USD", "value": "100.00"}}},
+				"configuration": {"tax_calculated_after_discount": true}
+			}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	invoice, err := client.GetInvoice("INV2-Z56S-5LLA-Q52L-CPZ5")
+
+	if err != nil {
+		t.Errorf("Cannot get invoice: %v", err)
+		t.FailNow()
+	}
+
+	if invoice.Status != InvoiceStatusSent || invoice.Detail.InvoiceNumber != "0042" || len(invoice.Items) != 1 {
+		t.Errorf("Unexpected invoice %+v", invoice)
+		t.FailNow()
+	}
+
+	if invoice.Amount.Breakdown.ItemTotal.Value != "100.00" || invoice.Configuration.TaxCalculatedAfterDiscount == nil || !*invoice.Configuration.TaxCalculatedAfterDiscount {
+		t.Errorf("Unexpected invoice amount %+v and configuration %+v", invoice.Amount, invoice.Configuration)
+		t.FailNow()
+	}
+}
+
+func TestClient_SearchInvoices(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v2/invoicing/invoices": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page_size") != "20" || r.URL.Query().Get("total_required") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"items": [{"id": "INV2-1"}, {"id": "INV2-2"}], "total_items": 2, "total_pages": 1}`))
+		},
+		"POST /v2/invoicing/search-invoices": func(w http.ResponseWriter, r *http.Request) {
+			search := InvoiceSearch{}
+
+			if err := json.NewDecoder(r.Body).Decode(&search); err != nil || len(search.Status) != 1 || r.URL.Query().Get("page") != "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"items": [{"id": "INV2-2", "status": "SENT"}], "total_items": 1}`))
+		},
+		"POST /v2/invoicing/invoices/INV2-2/generate-qr-code": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(base64.StdEncoding.EncodeToString(png)))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	list, err := client.ListInvoices(InvoiceListParams{PageSize: 20, TotalRequired: true})
+
+	if err != nil {
+		t.Errorf("Cannot list invoices: %v", err)
+		t.FailNow()
+	}
+
+	if len(list.Items) != 2 || list.TotalItems != 2 {
+		t.Errorf("Unexpected invoices %+v", list)
+		t.FailNow()
+	}
+
+	list, err = client.SearchInvoices(InvoiceSearch{Status: []string{InvoiceStatusSent}}, InvoiceListParams{Page: 2})
+
+	if err != nil {
+		t.Errorf("Cannot search invoices: %v", err)
+		t.FailNow()
+	}
+
+	if len(list.Items) != 1 || list.Items[0].Status != InvoiceStatusSent {
+		t.Errorf("Unexpected found invoices %+v", list)
+		t.FailNow()
+	}
+
+	qr, err := client.GenerateInvoiceQRCode("INV2-2", InvoiceQRCodeRequest{Width: 400, Height: 400})
+
+	if err != nil {
+		t.Errorf("Cannot generate invoice QR code: %v", err)
+		t.FailNow()
+	}
+
+	if !bytes.Equal(qr, png) {
+		t.Errorf("Unexpected QR code image %q", qr)
+		t.FailNow()
+	}
+}
+
+func TestClient_InvoiceTemplates(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v2/invoicing/templates": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "TEMP-19V05281TU309413B", "name": "Hours template", "unit_of_measure": "HOURS"}`))
+		},
+		"GET /v2/invoicing/templates": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("fields") != "all" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"templates": [{"id": "TEMP-19V05281TU309413B", "name": "Hours template", "default_template": true}]}`))
+		},
+		"GET /v2/invoicing/templates/TEMP-19V05281TU309413B": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "TEMP-19V05281TU309413B", "name": "Hours template", "unit_of_measure": "HOURS", "template_info": {"detail": {"currency_code": "USD"}}}`))
+		},
+		"PUT /v2/invoicing/templates/TEMP-19V05281TU309413B": func(w http.ResponseWriter, r *http.Request) {
+			template := InvoiceTemplate{}
+
+			if err := json.NewDecoder(r.Body).Decode(&template); err != nil || template.Name != "Hours template" || template.UnitOfMeasure != "AMOUNT" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"id": "TEMP-19V05281TU309413B", "name": "Hours template", "unit_of_measure": "AMOUNT"}`))
+		},
+		"DELETE /v2/invoicing/templates/TEMP-19V05281TU309413B": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	template, err := client.CreateInvoiceTemplate(InvoiceTemplate{
+		Name:          "Hours template",
+		UnitOfMeasure: "HOURS",
+		TemplateInfo:  &TemplateInfo{Detail: &InvoiceDetail{CurrencyCode: "USD"}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create invoice template: %v", err)
+		t.FailNow()
+	}
+
+	if template.ID != "TEMP-19V05281TU309413B" || template.RequestID == "" {
+		t.Errorf("Unexpected created invoice template %+v", template)
+		t.FailNow()
+	}
+
+	templates, err := client.ListInvoiceTemplates()
+
+	if err != nil {
+		t.Errorf("Cannot list invoice templates: %v", err)
+		t.FailNow()
+	}
+
+	if len(templates) != 1 || !templates[0].DefaultTemplate {
+		t.Errorf("Unexpected invoice templates %+v", templates)
+		t.FailNow()
+	}
+
+	template, err = client.GetInvoiceTemplate(template.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get invoice template: %v", err)
+		t.FailNow()
+	}
+
+	if template.UnitOfMeasure != "HOURS" || template.TemplateInfo == nil || template.TemplateInfo.Detail.CurrencyCode != "USD" {
+		t.Errorf("Unexpected invoice template %+v", template)
+		t.FailNow()
+	}
+
+	template.UnitOfMeasure = "AMOUNT"
+
+	template, err = client.UpdateInvoiceTemplate(template.ID, *template)
+
+	if err != nil {
+		t.Errorf("Cannot update invoice template: %v", err)
+		t.FailNow()
+	}
+
+	if template.UnitOfMeasure != "AMOUNT" {
+		t.Errorf("Unexpected updated invoice template unit. Got %v expected %v", template.UnitOfMeasure, "AMOUNT")
+		t.FailNow()
+	}
+
+	if err := client.DeleteInvoiceTemplate(template.ID); err != nil {
+		t.Errorf("Cannot delete invoice template: %v", err)
+		t.FailNow()
+	}
+}
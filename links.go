@@ -0,0 +1,74 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Links is a list of HATEOAS links returned along with PayPal resources
+type Links []Link
+
+// ByRel returns the link with the given relation
+func (l Links) ByRel(rel string) (Link, bool) {
+	for _, link := range l {
+		if link.Rel == rel {
+			return link, true
+		}
+	}
+
+	return Link{}, false
+}
+
+// ApprovalURL returns the URL the buyer must be redirected to in order to approve the payment.
+// It handles both the v1 approval_url and the v2 approve and payer-action relations
+func (l Links) ApprovalURL() string {
+	for _, rel := range []string{"approval_url", "approve", "payer-action"} {
+		if link, ok := l.ByRel(rel); ok {
+			return link.Href
+		}
+	}
+
+	return ""
+}
+
+// LinkByRel returns the payment link with the given relation
+func (r *PaymentResponse) LinkByRel(rel string) (Link, bool) {
+	return r.Links.ByRel(rel)
+}
+
+// ApprovalURL returns the URL the buyer must be redirected to in order to approve the payment
+func (r *PaymentResponse) ApprovalURL() string {
+	return r.Links.ApprovalURL()
+}
+
+// LinkByRel returns the order link with the given relation
+func (o *Order) LinkByRel(rel string) (Link, bool) {
+	return o.Links.ByRel(rel)
+}
+
+// ApprovalURL returns the URL the buyer must be redirected to in order to approve the order
+func (o *Order) ApprovalURL() string {
+	return o.Links.ApprovalURL()
+}
+
+// FollowLink runs an authorized request to the given link sending in as the JSON request body and
+// unmarshalling the JSON response into out. Nil in or out values are skipped. The link is requested
+// through the client base URL. Redirect links are meant for the buyer browser and cannot be followed
+func (c Client) FollowLink(ctx context.Context, link Link, in, out interface{}) error {
+	// Get link method
+	method := strings.ToUpper(link.Method)
+
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	if method == "REDIRECT" {
+		return fmt.Errorf("cannot follow %v redirect link", link.Rel)
+	}
+
+	_, err := c.JSONRequest(ctx, method, linkEndpoint(link.Href), in, out)
+
+	return err
+}
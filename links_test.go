@@ -0,0 +1,80 @@
+package gopaypal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLinks_ApprovalURL(t *testing.T) {
+	payment := PaymentResponse{
+		Links: Links{
+			{Href: "https://api.sandbox.paypal.com/v1/payments/payment/PAY-1", Rel: "self", Method: "GET"},
+			{Href: "https://www.sandbox.paypal.com/cgi-bin/webscr?cmd=_express-checkout&token=EC-1", Rel: "approval_url", Method: "REDIRECT"},
+		},
+	}
+
+	if payment.ApprovalURL() != payment.Links[1].Href {
+		t.Errorf("Unexpected approval URL %v", payment.ApprovalURL())
+		t.FailNow()
+	}
+
+	order := Order{
+		Links: Links{
+			{Href: "https://www.sandbox.paypal.com/checkoutnow?token=5O190127TN364715T", Rel: "approve", Method: "GET"},
+		},
+	}
+
+	if order.ApprovalURL() != order.Links[0].Href {
+		t.Errorf("Unexpected approval URL %v", order.ApprovalURL())
+		t.FailNow()
+	}
+
+	if _, ok := order.LinkByRel("capture"); ok {
+		t.Error("Unexpected capture link")
+		t.FailNow()
+	}
+}
+
+func TestClient_FollowLink(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/payments/payment/PAY-1/execute": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "PAY-1", "state": "approved"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	payment := PaymentResponse{
+		Links: Links{
+			{Href: "https://www.sandbox.paypal.com/cgi-bin/webscr?cmd=_express-checkout&token=EC-1", Rel: "approval_url", Method: "REDIRECT"},
+			{Href: "https://api.sandbox.paypal.com/v1/payments/payment/PAY-1/execute", Rel: "execute", Method: "POST"},
+		},
+	}
+
+	// Follow execute link
+	link, _ := payment.LinkByRel("execute")
+
+	res := PaymentResponse{}
+
+	if err := client.FollowLink(context.Background(), link, map[string]string{"payer_id": "QYR5Z8XDVJNXQ"}, &res); err != nil {
+		t.Errorf("Cannot follow execute link: %v", err)
+		t.FailNow()
+	}
+
+	if res.State != "approved" {
+		t.Errorf("Unexpected payment state. Got %v expected %v", res.State, "approved")
+		t.FailNow()
+	}
+
+	// Redirect links cannot be followed
+	link, _ = payment.LinkByRel("approval_url")
+
+	if err := client.FollowLink(context.Background(), link, nil, nil); err == nil {
+		t.Error("Expected redirect link error")
+		t.FailNow()
+	}
+}
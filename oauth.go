@@ -1,28 +1,68 @@
 package gopaypal
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 )
 
-type oauthResponse struct {
-	Scope       []string  `json:"scope"`
+// OAuthResponse is an OAuth2 access token issued to the client application. Expires is set by the
+// client from ExpiresIn when the token is received
+type OAuthResponse struct {
+	Scope       string    `json:"scope"`
 	Nonce       string    `json:"nonce"`
 	AccessToken string    `json:"access_token"`
 	TokenType   string    `json:"token_type"`
 	AppID       string    `json:"app_id"`
-	ExpiresIn   int       `json"expires_in"`
+	ExpiresIn   int       `json:"expires_in"`
 	Expires     time.Time `json:"-"`
 }
 
-// GetAccessToken gets the OAuth2 token from the PayPal endpoint
-func (c *Client) GetAccessToken() (*oauthResponse, error) {
+// GetAccessToken gets a new OAuth2 token from the PayPal endpoint and sets it as the client access token
+func (c *Client) GetAccessToken() (*OAuthResponse, error) {
+	return c.GetAccessTokenWithContext(context.Background())
+}
+
+// GetAccessTokenWithContext gets a new OAuth2 token from the PayPal endpoint using the given context
+// and sets it as the client access token
+func (c *Client) GetAccessTokenWithContext(ctx context.Context) (*OAuthResponse, error) {
+	// Request new access token
+	tkn, err := c.requestAccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Set client access token
+	if err := c.tokens.set(ctx, tkn); err != nil {
+		return nil, err
+	}
+
+	c.AccessToken = tkn
+
+	return tkn, nil
+}
+
+// Token returns the current client access token, requesting a new one when it is missing or about
+// to expire
+func (c Client) Token() (*OAuthResponse, error) {
+	return c.TokenWithContext(context.Background())
+}
+
+// TokenWithContext returns the current client access token using the given context, requesting a
+// new one when it is missing or about to expire
+func (c Client) TokenWithContext(ctx context.Context) (*OAuthResponse, error) {
+	return c.tokens.get(ctx, c.requestAccessToken)
+}
+
+// requestAccessToken requests a new OAuth2 token from the PayPal endpoint
+func (c Client) requestAccessToken(ctx context.Context) (*OAuthResponse, error) {
 	// Set grant_type
 	buff := []byte("grant_type=client_credentials")
 
 	// Create new gopaypal basic request
-	req, err := c.BasicRequest(OAuthURL, buff, http.MethodPost)
+	req, err := c.BasicRequestWithContext(ctx, OAuthURL, buff, http.MethodPost)
 
 	if err != nil {
 		return nil, err
@@ -31,6 +71,9 @@ func (c *Client) GetAccessToken() (*oauthResponse, error) {
 	// Set basic HTTP authentication
 	req.SetBasicAuth(c.clientID, c.secret)
 
+	// Set form content type
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
 	// Execute request
 	res, err := c.Execute(req)
 
@@ -39,18 +82,15 @@ func (c *Client) GetAccessToken() (*oauthResponse, error) {
 	}
 
 	// Parse response as JSON
-	oauthres := oauthResponse{}
+	oauthres := OAuthResponse{}
 
 	// Unmarshal response
-	if json.Unmarshal(res, &oauthres); err != nil {
+	if err := json.Unmarshal(res, &oauthres); err != nil {
 		return nil, err
 	}
 
-	// Set client access token
-	c.AccessToken = &oauthres
-
 	// Set token expires in time
-	c.AccessToken.Expires = time.Now().Add(time.Duration(c.AccessToken.ExpiresIn))
+	oauthres.Expires = time.Now().Add(time.Duration(oauthres.ExpiresIn) * time.Second)
 
 	return &oauthres, nil
 }
@@ -2,6 +2,7 @@ package gopaypal
 
 import (
 	"flag"
+	"os"
 	"testing"
 )
 
@@ -36,8 +37,21 @@ func init() {
 
 	// Get payer ID
 	flag.StringVar(&payerID, "payerid", "", "PayPal payer ID")
+}
 
+func TestMain(m *testing.M) {
+	// Parse flags once the testing flags are registered
 	flag.Parse()
+
+	os.Exit(m.Run())
+}
+
+// skipWithoutSandbox skips tests calling the PayPal sandbox when no credentials are given. Use the
+// paypaltest package to run them offline
+func skipWithoutSandbox(t *testing.T) {
+	if clientID == "" || secret == "" {
+		t.Skip("PayPal sandbox credentials not set. Use the flags -clientid and -secret")
+	}
 }
 
 func TestClient_GetAccessToken(t *testing.T) {
@@ -45,6 +59,8 @@ func TestClient_GetAccessToken(t *testing.T) {
 		t.SkipNow()
 	}
 
+	skipWithoutSandbox(t)
+
 	// Create gopaypal client
 	client := NewClient(clientID, secret, SandBoxURL)
 
@@ -0,0 +1,49 @@
+package gopaypal
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClientOption configures a gopaypal client on creation
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used to run every request. The client is shared, so
+// connections are reused between calls
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the time limit of every request made by the client
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		hc := c.copyHTTPClient()
+		hc.Timeout = d
+
+		c.httpClient = hc
+	}
+}
+
+// WithTransport sets the HTTP transport used to run every request. It can be used to set a proxy,
+// tune connection pooling or point tests at a fake server
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		hc := c.copyHTTPClient()
+		hc.Transport = rt
+
+		c.httpClient = hc
+	}
+}
+
+// copyHTTPClient returns a copy of the client HTTP client so a shared one is not modified
+func (c *Client) copyHTTPClient() *http.Client {
+	hc := http.Client{}
+
+	if c.httpClient != nil {
+		hc = *c.httpClient
+	}
+
+	return &hc
+}
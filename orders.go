@@ -0,0 +1,312 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	OrderIntentCapture   = "CAPTURE"
+	OrderIntentAuthorize = "AUTHORIZE"
+)
+
+// Order is a PayPal checkout order (Orders v2 API)
+type Order struct {
+	ID            string         `json:"id"`
+	Status        string         `json:"status"`
+	Intent        string         `json:"intent,omitempty"`
+	Payer         *OrderPayer    `json:"payer,omitempty"`
+	PurchaseUnits []PurchaseUnit `json:"purchase_units,omitempty"`
+	PaymentSource *PaymentSource `json:"payment_source,omitempty"`
+	CreateTime    time.Time      `json:"create_time"`
+	UpdateTime    time.Time      `json:"update_time"`
+	Links         Links          `json:"links,omitempty"`
+	RequestID     string         `json:"-"`
+}
+
+// OrderRequest holds the details of an order to create
+type OrderRequest struct {
+	Intent             string              `json:"intent"`
+	Payer              *OrderPayer         `json:"payer,omitempty"`
+	PurchaseUnits      []PurchaseUnit      `json:"purchase_units"`
+	PaymentSource      *PaymentSource      `json:"payment_source,omitempty"`
+	ApplicationContext *ApplicationContext `json:"application_context,omitempty"`
+}
+
+// AuthorizeOrderRequest holds the optional payment source used to authorize an order
+type AuthorizeOrderRequest struct {
+	PaymentSource *PaymentSource `json:"payment_source,omitempty"`
+}
+
+// CaptureOrderRequest holds the optional payment source used to capture an order
+type CaptureOrderRequest struct {
+	PaymentSource *PaymentSource `json:"payment_source,omitempty"`
+}
+
+// ConfirmOrderRequest holds the payment source the payer confirmed for an order
+type ConfirmOrderRequest struct {
+	PaymentSource      PaymentSource       `json:"payment_source"`
+	ApplicationContext *ApplicationContext `json:"application_context,omitempty"`
+}
+
+type Money struct {
+	CurrencyCode string `json:"currency_code"`
+	Value        string `json:"value"`
+}
+
+type PurchaseUnit struct {
+	ReferenceID    string               `json:"reference_id,omitempty"`
+	Amount         *AmountWithBreakdown `json:"amount,omitempty"`
+	Payee          *Payee               `json:"payee,omitempty"`
+	Description    string               `json:"description,omitempty"`
+	CustomID       string               `json:"custom_id,omitempty"`
+	InvoiceID      string               `json:"invoice_id,omitempty"`
+	SoftDescriptor string               `json:"soft_descriptor,omitempty"`
+	Items          []OrderItem          `json:"items,omitempty"`
+	Shipping       *ShippingDetail      `json:"shipping,omitempty"`
+	Payments       *PaymentCollection   `json:"payments,omitempty"`
+}
+
+type AmountWithBreakdown struct {
+	CurrencyCode string           `json:"currency_code"`
+	Value        string           `json:"value"`
+	Breakdown    *AmountBreakdown `json:"breakdown,omitempty"`
+}
+
+type AmountBreakdown struct {
+	ItemTotal        *Money `json:"item_total,omitempty"`
+	Shipping         *Money `json:"shipping,omitempty"`
+	Handling         *Money `json:"handling,omitempty"`
+	TaxTotal         *Money `json:"tax_total,omitempty"`
+	Insurance        *Money `json:"insurance,omitempty"`
+	ShippingDiscount *Money `json:"shipping_discount,omitempty"`
+	Discount         *Money `json:"discount,omitempty"`
+}
+
+type Payee struct {
+	EmailAddress string `json:"email_address,omitempty"`
+	MerchantID   string `json:"merchant_id,omitempty"`
+}
+
+type OrderItem struct {
+	Name        string `json:"name"`
+	UnitAmount  *Money `json:"unit_amount"`
+	Tax         *Money `json:"tax,omitempty"`
+	Quantity    string `json:"quantity"`
+	Description string `json:"description,omitempty"`
+	Sku         string `json:"sku,omitempty"`
+	Category    string `json:"category,omitempty"`
+}
+
+type ShippingDetail struct {
+	Name    *Name            `json:"name,omitempty"`
+	Type    string           `json:"type,omitempty"`
+	Address *AddressPortable `json:"address,omitempty"`
+}
+
+type Name struct {
+	GivenName string `json:"given_name,omitempty"`
+	Surname   string `json:"surname,omitempty"`
+	FullName  string `json:"full_name,omitempty"`
+}
+
+type AddressPortable struct {
+	AddressLine1 string `json:"address_line_1,omitempty"`
+	AddressLine2 string `json:"address_line_2,omitempty"`
+	AdminArea2   string `json:"admin_area_2,omitempty"`
+	AdminArea1   string `json:"admin_area_1,omitempty"`
+	PostalCode   string `json:"postal_code,omitempty"`
+	CountryCode  string `json:"country_code"`
+}
+
+type OrderPayer struct {
+	Name         *Name            `json:"name,omitempty"`
+	EmailAddress string           `json:"email_address,omitempty"`
+	PayerID      string           `json:"payer_id,omitempty"`
+	BirthDate    string           `json:"birth_date,omitempty"`
+	Address      *AddressPortable `json:"address,omitempty"`
+}
+
+type ApplicationContext struct {
+	BrandName          string `json:"brand_name,omitempty"`
+	Locale             string `json:"locale,omitempty"`
+	LandingPage        string `json:"landing_page,omitempty"`
+	ShippingPreference string `json:"shipping_preference,omitempty"`
+	UserAction         string `json:"user_action,omitempty"`
+	ReturnURL          string `json:"return_url,omitempty"`
+	CancelURL          string `json:"cancel_url,omitempty"`
+}
+
+type PaymentSource struct {
+	Card   *CardSource   `json:"card,omitempty"`
+	PayPal *PayPalWallet `json:"paypal,omitempty"`
+	Token  *TokenSource  `json:"token,omitempty"`
+}
+
+type CardSource struct {
+	Name           string           `json:"name,omitempty"`
+	Number         string           `json:"number,omitempty"`
+	Expiry         string           `json:"expiry,omitempty"`
+	SecurityCode   string           `json:"security_code,omitempty"`
+	BillingAddress *AddressPortable `json:"billing_address,omitempty"`
+	LastDigits     string           `json:"last_digits,omitempty"`
+	Brand          string           `json:"brand,omitempty"`
+	Type           string           `json:"type,omitempty"`
+}
+
+type PayPalWallet struct {
+	EmailAddress      string                   `json:"email_address,omitempty"`
+	AccountID         string                   `json:"account_id,omitempty"`
+	Name              *Name                    `json:"name,omitempty"`
+	Address           *AddressPortable         `json:"address,omitempty"`
+	ExperienceContext *PayPalExperienceContext `json:"experience_context,omitempty"`
+}
+
+type PayPalExperienceContext struct {
+	BrandName          string `json:"brand_name,omitempty"`
+	Locale             string `json:"locale,omitempty"`
+	LandingPage        string `json:"landing_page,omitempty"`
+	ShippingPreference string `json:"shipping_preference,omitempty"`
+	UserAction         string `json:"user_action,omitempty"`
+	ReturnURL          string `json:"return_url,omitempty"`
+	CancelURL          string `json:"cancel_url,omitempty"`
+}
+
+type TokenSource struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// PaymentCollection holds the payments made for a purchase unit
+type PaymentCollection struct {
+	Authorizations []AuthorizedPayment `json:"authorizations,omitempty"`
+	Captures       []CapturedPayment   `json:"captures,omitempty"`
+	Refunds        []PaymentRefund     `json:"refunds,omitempty"`
+}
+
+// AuthorizedPayment is an authorization of an order payment (Payments v2 API)
+type AuthorizedPayment struct {
+	ID             string    `json:"id"`
+	Status         string    `json:"status"`
+	Amount         *Money    `json:"amount,omitempty"`
+	InvoiceID      string    `json:"invoice_id,omitempty"`
+	CustomID       string    `json:"custom_id,omitempty"`
+	ExpirationTime time.Time `json:"expiration_time"`
+	CreateTime     time.Time `json:"create_time"`
+	UpdateTime     time.Time `json:"update_time"`
+	Links          Links     `json:"links,omitempty"`
+	RequestID      string    `json:"-"`
+}
+
+// CapturedPayment is a capture of an order payment (Payments v2 API)
+type CapturedPayment struct {
+	ID           string    `json:"id"`
+	Status       string    `json:"status"`
+	Amount       *Money    `json:"amount,omitempty"`
+	FinalCapture bool      `json:"final_capture,omitempty"`
+	InvoiceID    string    `json:"invoice_id,omitempty"`
+	CustomID     string    `json:"custom_id,omitempty"`
+	CreateTime   time.Time `json:"create_time"`
+	UpdateTime   time.Time `json:"update_time"`
+	Links        Links     `json:"links,omitempty"`
+	RequestID    string    `json:"-"`
+}
+
+// PaymentRefund is a refund of a captured payment (Payments v2 API)
+type PaymentRefund struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Amount      *Money    `json:"amount,omitempty"`
+	InvoiceID   string    `json:"invoice_id,omitempty"`
+	NoteToPayer string    `json:"note_to_payer,omitempty"`
+	CreateTime  time.Time `json:"create_time"`
+	UpdateTime  time.Time `json:"update_time"`
+	Links       Links     `json:"links,omitempty"`
+	RequestID   string    `json:"-"`
+}
+
+// CreateOrder creates a PayPal checkout order
+func (c Client) CreateOrder(order OrderRequest) (*Order, error) {
+	return c.CreateOrderWithContext(context.Background(), order)
+}
+
+// CreateOrderWithContext creates a PayPal checkout order using the given context
+func (c Client) CreateOrderWithContext(ctx context.Context, order OrderRequest) (*Order, error) {
+	return c.orderRequest(ctx, http.MethodPost, OrdersURL, &order)
+}
+
+// GetOrder gets the details of the order with the given ID
+func (c Client) GetOrder(orderID string) (*Order, error) {
+	return c.GetOrderWithContext(context.Background(), orderID)
+}
+
+// GetOrderWithContext gets the details of the order with the given ID using the given context
+func (c Client) GetOrderWithContext(ctx context.Context, orderID string) (*Order, error) {
+	return c.orderRequest(ctx, http.MethodGet, fmt.Sprintf(OrderURL, orderID), nil)
+}
+
+// UpdateOrder updates the order with the given ID applying the JSON Patch operations
+func (c Client) UpdateOrder(orderID string, patch Patch) error {
+	return c.UpdateOrderWithContext(context.Background(), orderID, patch)
+}
+
+// UpdateOrderWithContext updates the order with the given ID applying the JSON Patch operations
+// using the given context
+func (c Client) UpdateOrderWithContext(ctx context.Context, orderID string, patch Patch) error {
+	// Validate patch operations
+	if err := patch.Validate(); err != nil {
+		return err
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPatch, fmt.Sprintf(OrderURL, orderID), patch, nil)
+
+	return err
+}
+
+// AuthorizeOrder authorizes the payment of the approved order with the given ID
+func (c Client) AuthorizeOrder(orderID string, req AuthorizeOrderRequest) (*Order, error) {
+	return c.AuthorizeOrderWithContext(context.Background(), orderID, req)
+}
+
+// AuthorizeOrderWithContext authorizes the payment of the approved order with the given ID using the given context
+func (c Client) AuthorizeOrderWithContext(ctx context.Context, orderID string, req AuthorizeOrderRequest) (*Order, error) {
+	return c.orderRequest(ctx, http.MethodPost, fmt.Sprintf(OrderAuthorizeURL, orderID), &req)
+}
+
+// CaptureOrder captures the payment of the approved order with the given ID
+func (c Client) CaptureOrder(orderID string, req CaptureOrderRequest) (*Order, error) {
+	return c.CaptureOrderWithContext(context.Background(), orderID, req)
+}
+
+// CaptureOrderWithContext captures the payment of the approved order with the given ID using the given context
+func (c Client) CaptureOrderWithContext(ctx context.Context, orderID string, req CaptureOrderRequest) (*Order, error) {
+	return c.orderRequest(ctx, http.MethodPost, fmt.Sprintf(OrderCaptureURL, orderID), &req)
+}
+
+// ConfirmPaymentSource confirms the payment source of the order with the given ID
+func (c Client) ConfirmPaymentSource(orderID string, req ConfirmOrderRequest) (*Order, error) {
+	return c.ConfirmPaymentSourceWithContext(context.Background(), orderID, req)
+}
+
+// ConfirmPaymentSourceWithContext confirms the payment source of the order with the given ID using the given context
+func (c Client) ConfirmPaymentSourceWithContext(ctx context.Context, orderID string, req ConfirmOrderRequest) (*Order, error) {
+	return c.orderRequest(ctx, http.MethodPost, fmt.Sprintf(OrderConfirmURL, orderID), &req)
+}
+
+// orderRequest runs an order request and returns the resulting order
+func (c Client) orderRequest(ctx context.Context, method, endpoint string, in interface{}) (*Order, error) {
+	// Hold order response
+	d := Order{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, in, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
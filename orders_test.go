@@ -0,0 +1,187 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newAPIServer creates a fake PayPal server handling the given API endpoints
+func newAPIServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OAuthURL {
+			w.Write([]byte(`{"access_token": "A21AA", "expires_in": 32400}`))
+			return
+		}
+
+		h, ok := handlers[r.Method+" "+r.URL.Path]
+
+		if !ok {
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		h(w, r)
+	}))
+}
+
+func TestClient_CreateOrder(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v2/checkout/orders": func(w http.ResponseWriter, r *http.Request) {
+			o := OrderRequest{}
+
+			if err := json.NewDecoder(r.Body).Decode(&o); err != nil || o.Intent != OrderIntentCapture {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"id": "5O190127TN364715T",
+				"status": "CREATED",
+				"links": [{"href": "https://www.paypal.com/checkoutnow?token=5O190127TN364715T", "rel": "approve", "method": "GET"}]
+			}`))
+		},
+		"POST /v2/checkout/orders/5O190127TN364715T/capture": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"id": "5O190127TN364715T",
+				"status": "COMPLETED",
+				"purchase_units": [{
+					"reference_id": "default",
+					"payments": {"captures": [{"id": "3C679366HH908993F", "status": "COMPLETED", "amount": {"currency_code": "USD", "value": "100.00"}}]}
+				}]
+			}`))
+		},
+		"PATCH /v2/checkout/orders/5O190127TN364715T": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Create order
+	order, err := client.CreateOrder(OrderRequest{
+		Intent: OrderIntentCapture,
+		PurchaseUnits: []PurchaseUnit{
+			{
+				Amount: &AmountWithBreakdown{
+					CurrencyCode: "USD",
+					Value:        "100.00",
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create order: %v", err)
+		t.FailNow()
+	}
+
+	if order.Status != "CREATED" || order.RequestID == "" {
+		t.Errorf("Unexpected order status %v and request ID %v", order.Status, order.RequestID)
+		t.FailNow()
+	}
+
+	// Update order
+	err = client.UpdateOrder(order.ID, Patch{}.Replace("/purchase_units/@reference_id=='default'/amount", Money{CurrencyCode: "USD", Value: "100.00"}))
+
+	if err != nil {
+		t.Errorf("Cannot update order: %v", err)
+		t.FailNow()
+	}
+
+	// Capture order
+	order, err = client.CaptureOrder(order.ID, CaptureOrderRequest{})
+
+	if err != nil {
+		t.Errorf("Cannot capture order: %v", err)
+		t.FailNow()
+	}
+
+	if order.Status != "COMPLETED" || order.PurchaseUnits[0].Payments.Captures[0].ID != "3C679366HH908993F" {
+		t.Errorf("Unexpected captured order %+v", order)
+		t.FailNow()
+	}
+}
+
+func TestClient_AuthorizeOrder(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v2/checkout/orders/5O190127TN364715T/confirm-payment-source": func(w http.ResponseWriter, r *http.Request) {
+			req := ConfirmOrderRequest{}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PaymentSource.PayPal == nil || req.ApplicationContext == nil || req.ApplicationContext.ReturnURL != "https://example.com/return" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"id": "5O190127TN364715T", "status": "APPROVED"}`))
+		},
+		"POST /v2/checkout/orders/5O190127TN364715T/authorize": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"id": "5O190127TN364715T",
+				"status": "COMPLETED",
+				"purchase_units": [{
+					"reference_id": "default",
+					"payments": {"authorizations": [{"id": "0VF52814937998046", "status": "CREATED", "amount": {"currency_code": "USD", "value": "100.00"}}]}
+				}]
+			}`))
+		},
+		"GET /v2/checkout/orders/5O190127TN364715T": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "5O190127TN364715T", "intent": "AUTHORIZE", "status": "COMPLETED"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Confirm payment source
+	order, err := client.ConfirmPaymentSource("5O190127TN364715T", ConfirmOrderRequest{
+		PaymentSource:      PaymentSource{PayPal: &PayPalWallet{}},
+		ApplicationContext: &ApplicationContext{ReturnURL: "https://example.com/return"},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot confirm payment source: %v", err)
+		t.FailNow()
+	}
+
+	if order.Status != "APPROVED" || order.RequestID == "" {
+		t.Errorf("Unexpected order status %v and request ID %v", order.Status, order.RequestID)
+		t.FailNow()
+	}
+
+	// Authorize order
+	order, err = client.AuthorizeOrder(order.ID, AuthorizeOrderRequest{})
+
+	if err != nil {
+		t.Errorf("Cannot authorize order: %v", err)
+		t.FailNow()
+	}
+
+	if order.Status != "COMPLETED" || order.PurchaseUnits[0].Payments.Authorizations[0].ID != "0VF52814937998046" {
+		t.Errorf("Unexpected authorized order %+v", order)
+		t.FailNow()
+	}
+
+	// Get order
+	order, err = client.GetOrder(order.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get order: %v", err)
+		t.FailNow()
+	}
+
+	if order.ID != "5O190127TN364715T" || order.Intent != OrderIntentAuthorize {
+		t.Errorf("Unexpected order %+v", order)
+		t.FailNow()
+	}
+}
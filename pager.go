@@ -0,0 +1,85 @@
+package gopaypal
+
+import (
+	"context"
+)
+
+// pageFetcher fetches the list page at the given endpoint, keeping its items on the iterator. It
+// returns the number of items on the page and the endpoint of the next page, empty on the last one
+type pageFetcher func(ctx context.Context, endpoint string) (count int, next string, err error)
+
+// pager walks over the items of a paginated list, fetching pages as needed. It is embedded by the
+// list iterators, which hold the items of the current page
+type pager struct {
+	ctx      context.Context
+	fetch    pageFetcher
+	endpoint string
+	count    int
+	index    int
+	err      error
+}
+
+// newPager returns a pager starting at the page on the given endpoint
+func newPager(ctx context.Context, endpoint string, fetch pageFetcher) pager {
+	return pager{
+		ctx:      ctx,
+		fetch:    fetch,
+		endpoint: endpoint,
+	}
+}
+
+// next advances the pager to the next item. It returns false when there are no more items or an
+// error happened
+func (p *pager) next() bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.index++
+
+	// Fetch pages until a non empty one is found
+	for p.index >= p.count {
+		if p.endpoint == "" {
+			return false
+		}
+
+		count, next, err := p.fetch(p.ctx, p.endpoint)
+
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.count = count
+		p.index = 0
+		p.endpoint = next
+
+		// An empty page is the last one, even if it links to another
+		if count == 0 {
+			p.endpoint = ""
+		}
+	}
+
+	return true
+}
+
+// current returns the index of the current item on its page, or false before the first item and
+// after the last one
+func (p *pager) current() (int, bool) {
+	if p.index < 0 || p.index >= p.count {
+		return 0, false
+	}
+
+	return p.index, true
+}
+
+// nextLink returns the endpoint of the "next" link, or an empty endpoint when there is none
+func nextLink(links Links) string {
+	link, ok := links.ByRel("next")
+
+	if !ok {
+		return ""
+	}
+
+	return linkEndpoint(link.Href)
+}
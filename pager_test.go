@@ -0,0 +1,62 @@
+package gopaypal
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPager_Next(t *testing.T) {
+	pages := map[string][]int{
+		"/1": {1, 2},
+		"/2": {},
+		"/3": {3},
+	}
+
+	next := map[string]string{
+		"/1": "/2",
+		"/2": "/3",
+	}
+
+	fetched := []string{}
+
+	p := newPager(context.Background(), "/1", func(ctx context.Context, endpoint string) (int, string, error) {
+		fetched = append(fetched, endpoint)
+
+		return len(pages[endpoint]), next[endpoint], nil
+	})
+
+	if _, ok := p.current(); ok {
+		t.Errorf("Current item found before the first one")
+		t.FailNow()
+	}
+
+	items := 0
+
+	for p.next() {
+		items++
+	}
+
+	// Empty pages end the iteration even when they link to another
+	if items != 2 || len(fetched) != 2 || p.err != nil {
+		t.Errorf("Unexpected iteration. Got %v items from %v", items, fetched)
+		t.FailNow()
+	}
+
+	if _, ok := p.current(); ok {
+		t.Errorf("Current item found after the last one")
+		t.FailNow()
+	}
+
+	// Errors stop the iteration
+	failure := errors.New("unavailable")
+
+	p = newPager(context.Background(), "/1", func(ctx context.Context, endpoint string) (int, string, error) {
+		return 0, "", failure
+	})
+
+	if p.next() || p.next() || p.err != failure {
+		t.Errorf("Unexpected pager error %v", p.err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,93 @@
+package gopaypal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PatchOperation is a JSON Patch (RFC 6902) operation used to update PayPal resources
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+	From  string      `json:"from,omitempty"`
+}
+
+// Patch is a list of JSON Patch operations. It can be built by chaining its methods
+//
+//	patch := Patch{}.
+//		Replace("/transactions/0/amount", amount).
+//		Add("/transactions/0/invoice_number", "INV-1")
+type Patch []PatchOperation
+
+// Add returns the patch with an operation adding the value at the given path
+func (p Patch) Add(path string, value interface{}) Patch {
+	return p.with(PatchOperation{Op: "add", Path: path, Value: value})
+}
+
+// Replace returns the patch with an operation replacing the value at the given path
+func (p Patch) Replace(path string, value interface{}) Patch {
+	return p.with(PatchOperation{Op: "replace", Path: path, Value: value})
+}
+
+// Remove returns the patch with an operation removing the value at the given path
+func (p Patch) Remove(path string) Patch {
+	return p.with(PatchOperation{Op: "remove", Path: path})
+}
+
+// Move returns the patch with an operation moving the value at from to the given path
+func (p Patch) Move(from, path string) Patch {
+	return p.with(PatchOperation{Op: "move", Path: path, From: from})
+}
+
+// Copy returns the patch with an operation copying the value at from to the given path
+func (p Patch) Copy(from, path string) Patch {
+	return p.with(PatchOperation{Op: "copy", Path: path, From: from})
+}
+
+// with returns a copy of the patch followed by the given operation. The patch is copied so patches
+// built from a shared base never overwrite each other's operations
+func (p Patch) with(op PatchOperation) Patch {
+	return append(p[:len(p):len(p)], op)
+}
+
+// Validate checks every operation of the patch is well formed
+func (p Patch) Validate() error {
+	if len(p) == 0 {
+		return fmt.Errorf("empty patch")
+	}
+
+	for i, op := range p {
+		if err := op.Validate(); err != nil {
+			return fmt.Errorf("invalid patch operation %v: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the operation is well formed
+func (op PatchOperation) Validate() error {
+	if !strings.HasPrefix(op.Path, "/") {
+		return fmt.Errorf("path %q must start with /", op.Path)
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return fmt.Errorf("%v operation requires a value", op.Op)
+		}
+	case "remove":
+		if op.Value != nil {
+			return fmt.Errorf("remove operation does not take a value")
+		}
+	case "move", "copy":
+		if !strings.HasPrefix(op.From, "/") {
+			return fmt.Errorf("from %q must start with /", op.From)
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", op.Op)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestPatch_Validate(t *testing.T) {
+	// Build a valid patch
+	patch := Patch{}.
+		Replace("/transactions/0/amount", Amount{Total: "12", Currency: "EUR"}).
+		Add("/transactions/0/invoice_number", "INV-1").
+		Remove("/transactions/0/item_list/shipping_address")
+
+	if err := patch.Validate(); err != nil {
+		t.Errorf("Unexpected patch validation error: %v", err)
+		t.FailNow()
+	}
+
+	invalid := []Patch{
+		{},
+		Patch{}.Replace("transactions/0/amount", "12"),
+		Patch{}.Add("/transactions/0/invoice_number", nil),
+		{{Op: "remove", Path: "/transactions/0/custom", Value: "x"}},
+		{{Op: "merge", Path: "/transactions/0/custom", Value: "x"}},
+		Patch{}.Move("", "/transactions/0/custom"),
+	}
+
+	for i, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Invalid patch %v passed validation", i)
+			t.FailNow()
+		}
+	}
+}
+
+func TestPatch_SharedBase(t *testing.T) {
+	base := make(Patch, 0, 4).Replace("/transactions/0/amount", "12")
+
+	// Patches built from the same base keep their own operations
+	a := base.Add("/transactions/0/invoice_number", "INV-1")
+	b := base.Remove("/transactions/0/custom")
+
+	if len(base) != 1 || a[1].Op != "add" || b[1].Op != "remove" {
+		t.Errorf("Unexpected patches built from a shared base %+v %+v", a, b)
+		t.FailNow()
+	}
+}
+
+func TestClient_UpdatePayment(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"PATCH /v1/payments/payment/PAY-1": func(w http.ResponseWriter, r *http.Request) {
+			ops := []PatchOperation{}
+
+			if err := json.NewDecoder(r.Body).Decode(&ops); err != nil || len(ops) != 1 || ops[0].Op != "replace" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"id": "PAY-1", "state": "created", "transactions": [{"amount": {"total": "12", "currency": "EUR"}}]}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Update payment amount
+	res, err := client.UpdatePayment("PAY-1", Patch{}.Replace("/transactions/0/amount", Amount{Total: "12", Currency: "EUR"}))
+
+	if err != nil {
+		t.Errorf("Cannot update payment: %v", err)
+		t.FailNow()
+	}
+
+	if res.Transactions[0].Amount.Total != "12" {
+		t.Errorf("Unexpected payment amount. Got %v expected %v", res.Transactions[0].Amount.Total, "12")
+		t.FailNow()
+	}
+
+	// Invalid patches are not sent
+	if _, err := client.UpdatePayment("PAY-1", Patch{}.Remove("amount")); err == nil {
+		t.Error("Expected invalid patch error")
+		t.FailNow()
+	}
+}
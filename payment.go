@@ -1,13 +1,16 @@
 package gopaypal
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-type paymentCreateResponse struct {
+// PaymentResponse is a v1 PayPal payment as returned by CreatePayment, ExecutePayment, PaymentInformation
+// and UpdatePayment. RequestID holds the PayPal-Request-Id sent with mutating calls
+type PaymentResponse struct {
 	ID            string        `json:"id"`
 	CreateTime    time.Time     `json:"create_time"`
 	UpdateTime    time.Time     `json:"update_time"`
@@ -17,15 +20,18 @@ type paymentCreateResponse struct {
 	Transactions  []Transaction `json:"transactions,omitempty"`
 	FailureReason string        `json:"failure_reason,omitempty"`
 	RedirectURL   RedirectURL   `json:"redirect_urls,omitempty"`
-	Links         []Link        `json:"links"`
+	Links         Links         `json:"links"`
+	RequestID     string        `json:"-"`
 }
 
+// Link is a HATEOAS link to a related resource or action
 type Link struct {
 	Href   string `json:"href"`
 	Rel    string `json:"rel"`
 	Method string `json:"method"`
 }
 
+// Payment holds the details of a payment to create
 type Payment struct {
 	Intent       string        `json:"intent,omitempty"`
 	Payer        Payer         `json:"payer,omitempty"`
@@ -57,8 +63,14 @@ type Transaction struct {
 	RelatedResources []*RelatedResources `json:"related_resources,omitempty"`
 }
 
+// RelatedResources holds one of the resources created for a payment transaction. Only the field of
+// the resource present is set
 type RelatedResources struct {
-	Sale Sale `json:"sale,omitempty"`
+	Sale          *Sale          `json:"sale,omitempty"`
+	Authorization *Authorization `json:"authorization,omitempty"`
+	Order         *PaymentOrder  `json:"order,omitempty"`
+	Capture       *Capture       `json:"capture,omitempty"`
+	Refund        *Refund        `json:"refund,omitempty"`
 }
 
 type Sale struct {
@@ -70,6 +82,11 @@ type Sale struct {
 	ReasonCode              string `json:"reason_code,omitempty"`
 	ClearingTime            string `json:"clearing_time,omitempty"`
 	ReceiptID               string `json:"receipt_id,omitempty"`
+	ProtectionEligibility   string `json:"protection_eligibility,omitempty"`
+	ParentPayment           string `json:"parent_payment,omitempty"`
+	CreateTime              string `json:"create_time,omitempty"`
+	UpdateTime              string `json:"update_time,omitempty"`
+	Links                   Links  `json:"links,omitempty"`
 }
 
 type Amount struct {
@@ -114,9 +131,15 @@ type RedirectURL struct {
 	CancelURL string `json:"cancel_url,omitempty"`
 }
 
-func (c Client) PaymentInformation(paymentID string) (*paymentCreateResponse, error) {
+// PaymentInformation gets the details of the PayPal payment with the given ID
+func (c Client) PaymentInformation(paymentID string) (*PaymentResponse, error) {
+	return c.PaymentInformationWithContext(context.Background(), paymentID)
+}
+
+// PaymentInformationWithContext gets the details of the PayPal payment with the given ID using the given context
+func (c Client) PaymentInformationWithContext(ctx context.Context, paymentID string) (*PaymentResponse, error) {
 	// Create auth request
-	req, err := c.AuthRequest(fmt.Sprintf(
+	req, err := c.AuthRequestWithContext(ctx, fmt.Sprintf(
 		PaymentInfoURL,
 		paymentID,
 	), nil, http.MethodGet)
@@ -136,7 +159,7 @@ func (c Client) PaymentInformation(paymentID string) (*paymentCreateResponse, er
 	}
 
 	// Hold payment creation response
-	d := paymentCreateResponse{}
+	d := PaymentResponse{}
 
 	// Marshal response
 	if err := json.Unmarshal(res, &d); err != nil {
@@ -146,9 +169,15 @@ func (c Client) PaymentInformation(paymentID string) (*paymentCreateResponse, er
 	return &d, err
 }
 
-func (c Client) ExecutePayment(paymentID, payerID string) (*paymentCreateResponse, error) {
+// ExecutePayment executes the approved PayPal payment with the given payer ID
+func (c Client) ExecutePayment(paymentID, payerID string) (*PaymentResponse, error) {
+	return c.ExecutePaymentWithContext(context.Background(), paymentID, payerID)
+}
+
+// ExecutePaymentWithContext executes the approved PayPal payment with the given payer ID using the given context
+func (c Client) ExecutePaymentWithContext(ctx context.Context, paymentID, payerID string) (*PaymentResponse, error) {
 	// Create auth request
-	req, err := c.AuthRequest(fmt.Sprintf(
+	req, err := c.AuthRequestWithContext(ctx, fmt.Sprintf(
 		PaymentExecuteURL,
 		paymentID,
 	), []byte("{\"payer_id\": \""+payerID+"\"}"), http.MethodPost)
@@ -168,7 +197,9 @@ func (c Client) ExecutePayment(paymentID, payerID string) (*paymentCreateRespons
 	}
 
 	// Hold payment creation response
-	d := paymentCreateResponse{}
+	d := PaymentResponse{
+		RequestID: req.Header.Get(RequestIDHeader),
+	}
 
 	// Marshal response
 	if err := json.Unmarshal(res, &d); err != nil {
@@ -179,7 +210,12 @@ func (c Client) ExecutePayment(paymentID, payerID string) (*paymentCreateRespons
 }
 
 // CreatePayment creates a PayPal payment with the given payment object
-func (c Client) CreatePayment(payment Payment) (*paymentCreateResponse, error) {
+func (c Client) CreatePayment(payment Payment) (*PaymentResponse, error) {
+	return c.CreatePaymentWithContext(context.Background(), payment)
+}
+
+// CreatePaymentWithContext creates a PayPal payment with the given payment object using the given context
+func (c Client) CreatePaymentWithContext(ctx context.Context, payment Payment) (*PaymentResponse, error) {
 	// Marshal payment object to byte array
 	buff, err := json.Marshal(&payment)
 
@@ -188,7 +224,7 @@ func (c Client) CreatePayment(payment Payment) (*paymentCreateResponse, error) {
 	}
 
 	// Create auth request
-	req, err := c.AuthRequest(PaymentCreateURL, buff, http.MethodPost)
+	req, err := c.AuthRequestWithContext(ctx, PaymentCreateURL, buff, http.MethodPost)
 
 	if err != nil {
 		return nil, err
@@ -205,7 +241,9 @@ func (c Client) CreatePayment(payment Payment) (*paymentCreateResponse, error) {
 	}
 
 	// Hold payment creation response
-	d := paymentCreateResponse{}
+	d := PaymentResponse{
+		RequestID: req.Header.Get(RequestIDHeader),
+	}
 
 	// Marshal response
 	if err := json.Unmarshal(res, &d); err != nil {
@@ -214,3 +252,31 @@ func (c Client) CreatePayment(payment Payment) (*paymentCreateResponse, error) {
 
 	return &d, err
 }
+
+// UpdatePayment updates the payment with the given ID applying the JSON Patch operations. Only
+// payments not yet approved by the buyer can be updated
+func (c Client) UpdatePayment(paymentID string, patch Patch) (*PaymentResponse, error) {
+	return c.UpdatePaymentWithContext(context.Background(), paymentID, patch)
+}
+
+// UpdatePaymentWithContext updates the payment with the given ID applying the JSON Patch operations
+// using the given context
+func (c Client) UpdatePaymentWithContext(ctx context.Context, paymentID string, patch Patch) (*PaymentResponse, error) {
+	// Validate patch operations
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Hold payment update response
+	d := PaymentResponse{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPatch, fmt.Sprintf(PaymentUpdateURL, paymentID), patch, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
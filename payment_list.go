@@ -0,0 +1,196 @@
+package gopaypal
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PaymentListParams filters and paginates the payments returned by ListPayments. Zero values are not sent
+type PaymentListParams struct {
+	Count      int
+	StartID    string
+	StartIndex int
+	StartTime  time.Time
+	EndTime    time.Time
+	SortBy     string
+	SortOrder  string
+}
+
+// PaymentList is a page of payments
+type PaymentList struct {
+	Payments []PaymentResponse `json:"payments"`
+	Count    int               `json:"count"`
+	NextID   string            `json:"next_id,omitempty"`
+	Links    Links             `json:"links,omitempty"`
+}
+
+// PaymentIterator iterates over the payments matching the list parameters, fetching pages as needed
+//
+//	it := client.IteratePayments(ctx, params)
+//
+//	for it.Next() {
+//		payment := it.Payment()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PaymentIterator struct {
+	pager
+	page []PaymentResponse
+}
+
+// Query returns the list parameters encoded as an URL query
+func (p PaymentListParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.Count > 0 {
+		query.Set("count", strconv.Itoa(p.Count))
+	}
+
+	if p.StartID != "" {
+		query.Set("start_id", p.StartID)
+	}
+
+	if p.StartIndex > 0 {
+		query.Set("start_index", strconv.Itoa(p.StartIndex))
+	}
+
+	if !p.StartTime.IsZero() {
+		query.Set("start_time", p.StartTime.UTC().Format(time.RFC3339))
+	}
+
+	if !p.EndTime.IsZero() {
+		query.Set("end_time", p.EndTime.UTC().Format(time.RFC3339))
+	}
+
+	if p.SortBy != "" {
+		query.Set("sort_by", p.SortBy)
+	}
+
+	if p.SortOrder != "" {
+		query.Set("sort_order", p.SortOrder)
+	}
+
+	return query
+}
+
+// ListPayments gets a page of the payments matching the given parameters
+func (c Client) ListPayments(params PaymentListParams) (*PaymentList, error) {
+	return c.ListPaymentsWithContext(context.Background(), params)
+}
+
+// ListPaymentsWithContext gets a page of the payments matching the given parameters using the given context
+func (c Client) ListPaymentsWithContext(ctx context.Context, params PaymentListParams) (*PaymentList, error) {
+	return c.listPayments(ctx, paymentListEndpoint(params))
+}
+
+// IteratePayments returns an iterator over every payment matching the given parameters
+func (c Client) IteratePayments(ctx context.Context, params PaymentListParams) *PaymentIterator {
+	it := &PaymentIterator{}
+
+	it.pager = newPager(ctx, paymentListEndpoint(params), func(ctx context.Context, endpoint string) (int, string, error) {
+		list, err := c.listPayments(ctx, endpoint)
+
+		if err != nil {
+			return 0, "", err
+		}
+
+		it.page = list.Payments
+
+		return len(list.Payments), list.nextEndpoint(endpoint), nil
+	})
+
+	return it
+}
+
+// Next advances the iterator to the next payment. It returns false when there are no more
+// payments or an error happened
+func (it *PaymentIterator) Next() bool {
+	return it.next()
+}
+
+// Payment returns the current payment
+func (it *PaymentIterator) Payment() *PaymentResponse {
+	i, ok := it.current()
+
+	if !ok {
+		return nil
+	}
+
+	return &it.page[i]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *PaymentIterator) Err() error {
+	return it.err
+}
+
+// listPayments gets the payment page at the given endpoint
+func (c Client) listPayments(ctx context.Context, endpoint string) (*PaymentList, error) {
+	// Hold payment list response
+	d := PaymentList{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, endpoint, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// nextEndpoint returns the endpoint of the page following the one fetched from the given endpoint.
+// The next_page link is preferred over the next_id value. An empty endpoint is returned on the last page
+func (l *PaymentList) nextEndpoint(current string) string {
+	if len(l.Payments) == 0 {
+		return ""
+	}
+
+	if link, ok := l.Links.ByRel("next_page"); ok {
+		return linkEndpoint(link.Href)
+	}
+
+	if l.NextID == "" {
+		return ""
+	}
+
+	// Set the next start ID on the current endpoint
+	u, err := url.Parse(current)
+
+	if err != nil {
+		return ""
+	}
+
+	query := u.Query()
+
+	query.Set("start_id", l.NextID)
+	query.Del("start_index")
+
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// paymentListEndpoint returns the payment list endpoint with the given parameters
+func paymentListEndpoint(params PaymentListParams) string {
+	query := params.Query()
+
+	if len(query) == 0 {
+		return PaymentListURL
+	}
+
+	return PaymentListURL + "?" + query.Encode()
+}
+
+// linkEndpoint returns the path and query of the given link, so it is requested through the client base URL
+func linkEndpoint(href string) string {
+	u, err := url.Parse(href)
+
+	if err != nil {
+		return href
+	}
+
+	return u.RequestURI()
+}
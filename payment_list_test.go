@@ -0,0 +1,53 @@
+package gopaypal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_IteratePayments(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/payments/payment": func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("start_id") {
+			case "":
+				if r.URL.Query().Get("count") != "2" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				w.Write([]byte(`{"payments": [{"id": "PAY-1"}, {"id": "PAY-2"}], "count": 2, "next_id": "PAY-3"}`))
+			case "PAY-3":
+				w.Write([]byte(`{"payments": [{"id": "PAY-3"}], "count": 1, "links": [
+					{"href": "https://api.sandbox.paypal.com/v1/payments/payment?count=2&start_id=PAY-4", "rel": "next_page", "method": "GET"}
+				]}`))
+			default:
+				w.Write([]byte(`{"payments": [], "count": 0}`))
+			}
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Iterate over every payment
+	it := client.IteratePayments(context.Background(), PaymentListParams{Count: 2})
+
+	ids := []string{}
+
+	for it.Next() {
+		ids = append(ids, it.Payment().ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Errorf("Cannot list payments: %v", err)
+		t.FailNow()
+	}
+
+	if len(ids) != 3 || ids[0] != "PAY-1" || ids[2] != "PAY-3" {
+		t.Errorf("Unexpected payments %v", ids)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,230 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type Authorization struct {
+	ID                    string `json:"id,omitempty"`
+	Amount                Amount `json:"amount,omitempty"`
+	PaymentMode           string `json:"payment_mode,omitempty"`
+	State                 string `json:"state,omitempty"`
+	ReasonCode            string `json:"reason_code,omitempty"`
+	PendingReason         string `json:"pending_reason,omitempty"`
+	ProtectionEligibility string `json:"protection_eligibility,omitempty"`
+	ParentPayment         string `json:"parent_payment,omitempty"`
+	ValidUntil            string `json:"valid_until,omitempty"`
+	CreateTime            string `json:"create_time,omitempty"`
+	UpdateTime            string `json:"update_time,omitempty"`
+	Links                 Links  `json:"links,omitempty"`
+	RequestID             string `json:"-"`
+}
+
+// PaymentOrder is the order resource of a payment created with the order intent
+type PaymentOrder struct {
+	ID                      string `json:"id,omitempty"`
+	PurchaseUnitReferenceID string `json:"purchase_unit_reference_id,omitempty"`
+	Amount                  Amount `json:"amount,omitempty"`
+	PaymentMode             string `json:"payment_mode,omitempty"`
+	State                   string `json:"state,omitempty"`
+	ReasonCode              string `json:"reason_code,omitempty"`
+	PendingReason           string `json:"pending_reason,omitempty"`
+	ParentPayment           string `json:"parent_payment,omitempty"`
+	CreateTime              string `json:"create_time,omitempty"`
+	UpdateTime              string `json:"update_time,omitempty"`
+	Links                   Links  `json:"links,omitempty"`
+}
+
+type Capture struct {
+	ID             string    `json:"id,omitempty"`
+	Amount         Amount    `json:"amount,omitempty"`
+	IsFinalCapture bool      `json:"is_final_capture,omitempty"`
+	State          string    `json:"state,omitempty"`
+	ReasonCode     string    `json:"reason_code,omitempty"`
+	ParentPayment  string    `json:"parent_payment,omitempty"`
+	InvoiceNumber  string    `json:"invoice_number,omitempty"`
+	TransactionFee *Currency `json:"transaction_fee,omitempty"`
+	CreateTime     string    `json:"create_time,omitempty"`
+	UpdateTime     string    `json:"update_time,omitempty"`
+	Links          Links     `json:"links,omitempty"`
+	RequestID      string    `json:"-"`
+}
+
+type Refund struct {
+	ID            string `json:"id,omitempty"`
+	Amount        Amount `json:"amount,omitempty"`
+	State         string `json:"state,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	InvoiceNumber string `json:"invoice_number,omitempty"`
+	SaleID        string `json:"sale_id,omitempty"`
+	CaptureID     string `json:"capture_id,omitempty"`
+	ParentPayment string `json:"parent_payment,omitempty"`
+	Description   string `json:"description,omitempty"`
+	CreateTime    string `json:"create_time,omitempty"`
+	UpdateTime    string `json:"update_time,omitempty"`
+	Links         Links  `json:"links,omitempty"`
+	RequestID     string `json:"-"`
+}
+
+type Currency struct {
+	Currency string `json:"currency"`
+	Value    string `json:"value"`
+}
+
+// SaleRefundRequest holds the details of a refund of a sale or a capture. A nil amount refunds
+// the whole amount
+type SaleRefundRequest struct {
+	Amount        *Amount `json:"amount,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Reason        string  `json:"reason,omitempty"`
+	InvoiceNumber string  `json:"invoice_number,omitempty"`
+}
+
+// GetSale gets the details of the sale with the given ID
+func (c Client) GetSale(saleID string) (*Sale, error) {
+	return c.GetSaleWithContext(context.Background(), saleID)
+}
+
+// GetSaleWithContext gets the details of the sale with the given ID using the given context
+func (c Client) GetSaleWithContext(ctx context.Context, saleID string) (*Sale, error) {
+	// Hold sale response
+	d := Sale{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(SaleURL, saleID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// RefundSale refunds the completed sale with the given ID
+func (c Client) RefundSale(saleID string, req SaleRefundRequest) (*Refund, error) {
+	return c.RefundSaleWithContext(context.Background(), saleID, req)
+}
+
+// RefundSaleWithContext refunds the completed sale with the given ID using the given context
+func (c Client) RefundSaleWithContext(ctx context.Context, saleID string, req SaleRefundRequest) (*Refund, error) {
+	return c.refundRequest(ctx, fmt.Sprintf(SaleRefundURL, saleID), req)
+}
+
+// GetAuthorization gets the details of the authorization with the given ID
+func (c Client) GetAuthorization(authorizationID string) (*Authorization, error) {
+	return c.GetAuthorizationWithContext(context.Background(), authorizationID)
+}
+
+// GetAuthorizationWithContext gets the details of the authorization with the given ID using the given context
+func (c Client) GetAuthorizationWithContext(ctx context.Context, authorizationID string) (*Authorization, error) {
+	// Hold authorization response
+	d := Authorization{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(AuthorizationURL, authorizationID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// CaptureAuthorization captures the given amount of the authorization with the given ID
+func (c Client) CaptureAuthorization(authorizationID string, capture Capture) (*Capture, error) {
+	return c.CaptureAuthorizationWithContext(context.Background(), authorizationID, capture)
+}
+
+// CaptureAuthorizationWithContext captures the given amount of the authorization with the given ID
+// using the given context
+func (c Client) CaptureAuthorizationWithContext(ctx context.Context, authorizationID string, capture Capture) (*Capture, error) {
+	// Hold capture response
+	d := Capture{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(AuthorizationCaptureURL, authorizationID), &capture, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// VoidAuthorization voids the authorization with the given ID
+func (c Client) VoidAuthorization(authorizationID string) (*Authorization, error) {
+	return c.VoidAuthorizationWithContext(context.Background(), authorizationID)
+}
+
+// VoidAuthorizationWithContext voids the authorization with the given ID using the given context
+func (c Client) VoidAuthorizationWithContext(ctx context.Context, authorizationID string) (*Authorization, error) {
+	// Hold authorization response
+	d := Authorization{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(AuthorizationVoidURL, authorizationID), nil, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// GetCapture gets the details of the capture with the given ID
+func (c Client) GetCapture(captureID string) (*Capture, error) {
+	return c.GetCaptureWithContext(context.Background(), captureID)
+}
+
+// GetCaptureWithContext gets the details of the capture with the given ID using the given context
+func (c Client) GetCaptureWithContext(ctx context.Context, captureID string) (*Capture, error) {
+	// Hold capture response
+	d := Capture{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(CaptureURL, captureID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// RefundCapture refunds the capture with the given ID
+func (c Client) RefundCapture(captureID string, req SaleRefundRequest) (*Refund, error) {
+	return c.RefundCaptureWithContext(context.Background(), captureID, req)
+}
+
+// RefundCaptureWithContext refunds the capture with the given ID using the given context
+func (c Client) RefundCaptureWithContext(ctx context.Context, captureID string, req SaleRefundRequest) (*Refund, error) {
+	return c.refundRequest(ctx, fmt.Sprintf(CaptureRefundURL, captureID), req)
+}
+
+// GetRefund gets the details of the refund with the given ID
+func (c Client) GetRefund(refundID string) (*Refund, error) {
+	return c.GetRefundWithContext(context.Background(), refundID)
+}
+
+// GetRefundWithContext gets the details of the refund with the given ID using the given context
+func (c Client) GetRefundWithContext(ctx context.Context, refundID string) (*Refund, error) {
+	// Hold refund response
+	d := Refund{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(RefundURL, refundID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// refundRequest runs a sale or capture refund request and returns the resulting refund
+func (c Client) refundRequest(ctx context.Context, endpoint string, req SaleRefundRequest) (*Refund, error) {
+	// Hold refund response
+	d := Refund{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, endpoint, &req, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
@@ -0,0 +1,225 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_RefundSale(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/payments/sale/36C38912MN9658832": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "36C38912MN9658832", "state": "completed", "amount": {"total": "7.47", "currency": "USD"}}`))
+		},
+		"POST /v1/payments/sale/36C38912MN9658832/refund": func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]interface{}{}
+
+			// Full refunds must not send an amount
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["amount"] != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "4CF18861HF410323U", "state": "completed", "sale_id": "36C38912MN9658832"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get sale
+	sale, err := client.GetSale("36C38912MN9658832")
+
+	if err != nil {
+		t.Errorf("Cannot get sale: %v", err)
+		t.FailNow()
+	}
+
+	if sale.State != "completed" {
+		t.Errorf("Unexpected sale state. Got %v expected %v", sale.State, "completed")
+		t.FailNow()
+	}
+
+	// Refund whole sale
+	refund, err := client.RefundSale(sale.ID, SaleRefundRequest{})
+
+	if err != nil {
+		t.Errorf("Cannot refund sale: %v", err)
+		t.FailNow()
+	}
+
+	if refund.SaleID != sale.ID || refund.RequestID == "" {
+		t.Errorf("Unexpected refund %+v", refund)
+		t.FailNow()
+	}
+}
+
+func TestClient_CaptureAuthorization(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/payments/authorization/2DC87612EK520411B": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "2DC87612EK520411B", "state": "authorized", "amount": {"total": "7.47", "currency": "USD"}}`))
+		},
+		"POST /v1/payments/authorization/2DC87612EK520411B/capture": func(w http.ResponseWriter, r *http.Request) {
+			capture := Capture{}
+
+			if err := json.NewDecoder(r.Body).Decode(&capture); err != nil || capture.Amount.Total != "4.54" || !capture.IsFinalCapture {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "8F148933LY9388354", "state": "completed", "is_final_capture": true, "amount": {"total": "4.54", "currency": "USD"}}`))
+		},
+		"POST /v1/payments/authorization/2DC87612EK520411B/void": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "2DC87612EK520411B", "state": "voided"}`))
+		},
+		"GET /v1/payments/capture/8F148933LY9388354": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "8F148933LY9388354", "state": "completed", "parent_payment": "PAY-1"}`))
+		},
+		"POST /v1/payments/capture/8F148933LY9388354/refund": func(w http.ResponseWriter, r *http.Request) {
+			req := SaleRefundRequest{}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Amount == nil || req.Amount.Total != "1.00" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "0P209507D6694645N", "state": "completed", "capture_id": "8F148933LY9388354"}`))
+		},
+		"GET /v1/payments/refund/0P209507D6694645N": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "0P209507D6694645N", "state": "completed", "capture_id": "8F148933LY9388354"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get authorization
+	auth, err := client.GetAuthorization("2DC87612EK520411B")
+
+	if err != nil {
+		t.Errorf("Cannot get authorization: %v", err)
+		t.FailNow()
+	}
+
+	if auth.State != "authorized" || auth.Amount.Total != "7.47" {
+		t.Errorf("Unexpected authorization %+v", auth)
+		t.FailNow()
+	}
+
+	// Capture part of the authorization
+	capture, err := client.CaptureAuthorization(auth.ID, Capture{
+		Amount:         Amount{Total: "4.54", Currency: "USD"},
+		IsFinalCapture: true,
+	})
+
+	if err != nil {
+		t.Errorf("Cannot capture authorization: %v", err)
+		t.FailNow()
+	}
+
+	if capture.ID != "8F148933LY9388354" || capture.RequestID == "" {
+		t.Errorf("Unexpected capture %+v", capture)
+		t.FailNow()
+	}
+
+	// Get capture
+	capture, err = client.GetCapture(capture.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get capture: %v", err)
+		t.FailNow()
+	}
+
+	if capture.ParentPayment != "PAY-1" {
+		t.Errorf("Unexpected capture parent payment. Got %v expected %v", capture.ParentPayment, "PAY-1")
+		t.FailNow()
+	}
+
+	// Refund part of the capture
+	refund, err := client.RefundCapture(capture.ID, SaleRefundRequest{Amount: &Amount{Total: "1.00", Currency: "USD"}})
+
+	if err != nil {
+		t.Errorf("Cannot refund capture: %v", err)
+		t.FailNow()
+	}
+
+	if refund.CaptureID != capture.ID || refund.RequestID == "" {
+		t.Errorf("Unexpected refund %+v", refund)
+		t.FailNow()
+	}
+
+	// Get refund
+	refund, err = client.GetRefund(refund.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get refund: %v", err)
+		t.FailNow()
+	}
+
+	if refund.State != "completed" {
+		t.Errorf("Unexpected refund state. Got %v expected %v", refund.State, "completed")
+		t.FailNow()
+	}
+
+	// Void authorization
+	auth, err = client.VoidAuthorization(auth.ID)
+
+	if err != nil {
+		t.Errorf("Cannot void authorization: %v", err)
+		t.FailNow()
+	}
+
+	if auth.State != "voided" || auth.RequestID == "" {
+		t.Errorf("Unexpected voided authorization %+v", auth)
+		t.FailNow()
+	}
+}
+
+func TestRelatedResources_Unmarshal(t *testing.T) {
+	b := []byte(`{"transactions": [{"related_resources": [
+		{"authorization": {"id": "2DC87612EK520411B", "state": "authorized"}},
+		{"capture": {"id": "8F148933LY9388354", "state": "completed", "is_final_capture": true}}
+	]}]}`)
+
+	// Hold payment response
+	d := PaymentResponse{}
+
+	if err := json.Unmarshal(b, &d); err != nil {
+		t.Errorf("Cannot unmarshal payment: %v", err)
+		t.FailNow()
+	}
+
+	res := d.Transactions[0].RelatedResources
+
+	if res[0].Authorization == nil || res[0].Authorization.ID != "2DC87612EK520411B" || res[0].Capture != nil {
+		t.Errorf("Unexpected related resources %+v", res[0])
+		t.FailNow()
+	}
+
+	if res[1].Capture == nil || res[1].Capture.ID != "8F148933LY9388354" || res[1].Authorization != nil {
+		t.Errorf("Unexpected related resources %+v", res[1])
+		t.FailNow()
+	}
+
+	// Missing resources are not written back
+	b, err := json.Marshal(res[0])
+
+	if err != nil {
+		t.Errorf("Cannot marshal related resources: %v", err)
+		t.FailNow()
+	}
+
+	fields := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(b, &fields); err != nil || len(fields) != 1 || fields["authorization"] == nil {
+		t.Errorf("Unexpected marshalled related resources %s", b)
+		t.FailNow()
+	}
+}
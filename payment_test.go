@@ -1,10 +1,16 @@
 package gopaypal
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestClient_CreatePayment(t *testing.T) {
+	skipWithoutSandbox(t)
+
 	// Create gopaypal client
 	client := NewClient(clientID, secret, SandBoxURL)
 
@@ -53,7 +59,7 @@ func TestClient_CreatePayment(t *testing.T) {
 	}
 
 	// Display payment link to user
-	t.Logf("This is the PayPal approve payment URL %v", res.Links[1].Href)
+	t.Logf("This is the PayPal approve payment URL %v", res.ApprovalURL())
 	t.Log("Use the flags -paymentid and -payerid to test the execution of a PayPal payment")
 }
 
@@ -88,3 +94,66 @@ func TestClient_ExecutePayment(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestClient_CreatePaymentRequestID(t *testing.T) {
+	var ids []string
+
+	// Create a fake PayPal server failing the first payment creation
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OAuthURL {
+			w.Write([]byte(`{"access_token": "A21AA", "expires_in": 32400}`))
+			return
+		}
+
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+
+		if len(ids) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "PAY-1", "state": "created"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}))
+
+	// Create payment with a caller provided request ID
+	ctx := ContextWithRequestID(context.Background(), "order-42")
+
+	res, err := client.CreatePaymentWithContext(ctx, Payment{Intent: "sale"})
+
+	if err != nil {
+		t.Errorf("Cannot create PayPal payment: %v", err)
+		t.FailNow()
+	}
+
+	if len(ids) != 2 || ids[0] != "order-42" || ids[1] != "order-42" {
+		t.Errorf("Request ID not reused across retries: %v", ids)
+		t.FailNow()
+	}
+
+	if res.RequestID != "order-42" {
+		t.Errorf("Unexpected response request ID. Got %v expected %v", res.RequestID, "order-42")
+		t.FailNow()
+	}
+
+	// Create payment with a generated request ID
+	res, err = client.CreatePayment(Payment{Intent: "sale"})
+
+	if err != nil {
+		t.Errorf("Cannot create PayPal payment: %v", err)
+		t.FailNow()
+	}
+
+	if res.RequestID == "" || res.RequestID != ids[len(ids)-1] {
+		t.Errorf("Unexpected generated request ID %v", res.RequestID)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,157 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CaptureAuthorizationRequest holds the details of a capture of an authorized payment. A nil amount
+// captures the whole authorized amount
+type CaptureAuthorizationRequest struct {
+	Amount         *Money `json:"amount,omitempty"`
+	InvoiceID      string `json:"invoice_id,omitempty"`
+	FinalCapture   bool   `json:"final_capture,omitempty"`
+	NoteToPayer    string `json:"note_to_payer,omitempty"`
+	SoftDescriptor string `json:"soft_descriptor,omitempty"`
+}
+
+// ReauthorizeRequest holds the amount of a reauthorization of an authorized payment
+type ReauthorizeRequest struct {
+	Amount *Money `json:"amount,omitempty"`
+}
+
+// RefundRequest holds the details of a refund of a captured payment. A nil amount refunds the
+// whole captured amount
+type RefundRequest struct {
+	Amount      *Money `json:"amount,omitempty"`
+	InvoiceID   string `json:"invoice_id,omitempty"`
+	CustomID    string `json:"custom_id,omitempty"`
+	NoteToPayer string `json:"note_to_payer,omitempty"`
+}
+
+// GetAuthorizedPayment gets the details of the authorized payment with the given ID
+func (c Client) GetAuthorizedPayment(authorizationID string) (*AuthorizedPayment, error) {
+	return c.GetAuthorizedPaymentWithContext(context.Background(), authorizationID)
+}
+
+// GetAuthorizedPaymentWithContext gets the details of the authorized payment with the given ID using the given context
+func (c Client) GetAuthorizedPaymentWithContext(ctx context.Context, authorizationID string) (*AuthorizedPayment, error) {
+	// Hold authorization response
+	d := AuthorizedPayment{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(AuthorizedPaymentURL, authorizationID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// CaptureAuthorizedPayment captures the authorized payment with the given ID
+func (c Client) CaptureAuthorizedPayment(authorizationID string, req CaptureAuthorizationRequest) (*CapturedPayment, error) {
+	return c.CaptureAuthorizedPaymentWithContext(context.Background(), authorizationID, req)
+}
+
+// CaptureAuthorizedPaymentWithContext captures the authorized payment with the given ID using the given context
+func (c Client) CaptureAuthorizedPaymentWithContext(ctx context.Context, authorizationID string, req CaptureAuthorizationRequest) (*CapturedPayment, error) {
+	// Hold capture response
+	d := CapturedPayment{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(AuthorizedPaymentCaptureURL, authorizationID), &req, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// ReauthorizeAuthorizedPayment reauthorizes the authorized payment with the given ID
+func (c Client) ReauthorizeAuthorizedPayment(authorizationID string, req ReauthorizeRequest) (*AuthorizedPayment, error) {
+	return c.ReauthorizeAuthorizedPaymentWithContext(context.Background(), authorizationID, req)
+}
+
+// ReauthorizeAuthorizedPaymentWithContext reauthorizes the authorized payment with the given ID using the given context
+func (c Client) ReauthorizeAuthorizedPaymentWithContext(ctx context.Context, authorizationID string, req ReauthorizeRequest) (*AuthorizedPayment, error) {
+	// Hold authorization response
+	d := AuthorizedPayment{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(AuthorizedPaymentReauthorizeURL, authorizationID), &req, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// VoidAuthorizedPayment voids the authorized payment with the given ID
+func (c Client) VoidAuthorizedPayment(authorizationID string) error {
+	return c.VoidAuthorizedPaymentWithContext(context.Background(), authorizationID)
+}
+
+// VoidAuthorizedPaymentWithContext voids the authorized payment with the given ID using the given context
+func (c Client) VoidAuthorizedPaymentWithContext(ctx context.Context, authorizationID string) error {
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(AuthorizedPaymentVoidURL, authorizationID), nil, nil)
+
+	return err
+}
+
+// GetCapturedPayment gets the details of the captured payment with the given ID
+func (c Client) GetCapturedPayment(captureID string) (*CapturedPayment, error) {
+	return c.GetCapturedPaymentWithContext(context.Background(), captureID)
+}
+
+// GetCapturedPaymentWithContext gets the details of the captured payment with the given ID using the given context
+func (c Client) GetCapturedPaymentWithContext(ctx context.Context, captureID string) (*CapturedPayment, error) {
+	// Hold capture response
+	d := CapturedPayment{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(CapturedPaymentURL, captureID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// RefundCapturedPayment refunds the captured payment with the given ID
+func (c Client) RefundCapturedPayment(captureID string, req RefundRequest) (*PaymentRefund, error) {
+	return c.RefundCapturedPaymentWithContext(context.Background(), captureID, req)
+}
+
+// RefundCapturedPaymentWithContext refunds the captured payment with the given ID using the given context
+func (c Client) RefundCapturedPaymentWithContext(ctx context.Context, captureID string, req RefundRequest) (*PaymentRefund, error) {
+	// Hold refund response
+	d := PaymentRefund{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(CapturedPaymentRefundURL, captureID), &req, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// GetPaymentRefund gets the details of the refund with the given ID
+func (c Client) GetPaymentRefund(refundID string) (*PaymentRefund, error) {
+	return c.GetPaymentRefundWithContext(context.Background(), refundID)
+}
+
+// GetPaymentRefundWithContext gets the details of the refund with the given ID using the given context
+func (c Client) GetPaymentRefundWithContext(ctx context.Context, refundID string) (*PaymentRefund, error) {
+	// Hold refund response
+	d := PaymentRefund{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(PaymentRefundURL, refundID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
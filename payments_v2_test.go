@@ -0,0 +1,166 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_CaptureAuthorizedPayment(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v2/payments/authorizations/0VF52814937998046/capture": func(w http.ResponseWriter, r *http.Request) {
+			req := CaptureAuthorizationRequest{}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Amount.Value != "10.99" || !req.FinalCapture {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "2GG279541U471931P", "status": "COMPLETED", "final_capture": true}`))
+		},
+		"POST /v2/payments/captures/2GG279541U471931P/refund": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "1JU08902781691411", "status": "COMPLETED"}`))
+		},
+		"POST /v2/payments/authorizations/0VF52814937998046/void": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Capture partial amount
+	capture, err := client.CaptureAuthorizedPayment("0VF52814937998046", CaptureAuthorizationRequest{
+		Amount:       &Money{CurrencyCode: "USD", Value: "10.99"},
+		InvoiceID:    "INVOICE-123",
+		FinalCapture: true,
+		NoteToPayer:  "Thank you",
+	})
+
+	if err != nil {
+		t.Errorf("Cannot capture authorized payment: %v", err)
+		t.FailNow()
+	}
+
+	if capture.Status != "COMPLETED" || !capture.FinalCapture {
+		t.Errorf("Unexpected capture %+v", capture)
+		t.FailNow()
+	}
+
+	// Refund capture
+	refund, err := client.RefundCapturedPayment(capture.ID, RefundRequest{})
+
+	if err != nil {
+		t.Errorf("Cannot refund captured payment: %v", err)
+		t.FailNow()
+	}
+
+	if refund.ID != "1JU08902781691411" {
+		t.Errorf("Unexpected refund ID %v", refund.ID)
+		t.FailNow()
+	}
+
+	// Void authorization
+	if err := client.VoidAuthorizedPayment("0VF52814937998046"); err != nil {
+		t.Errorf("Cannot void authorized payment: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestClient_ReauthorizeAuthorizedPayment(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v2/payments/authorizations/0VF52814937998046/reauthorize": func(w http.ResponseWriter, r *http.Request) {
+			req := ReauthorizeRequest{}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Amount == nil || req.Amount.Value != "10.99" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "8AA831015G517922L", "status": "CREATED", "amount": {"currency_code": "USD", "value": "10.99"}}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Reauthorize payment
+	auth, err := client.ReauthorizeAuthorizedPayment("0VF52814937998046", ReauthorizeRequest{
+		Amount: &Money{CurrencyCode: "USD", Value: "10.99"},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot reauthorize authorized payment: %v", err)
+		t.FailNow()
+	}
+
+	if auth.ID != "8AA831015G517922L" || auth.Status != "CREATED" || auth.RequestID == "" {
+		t.Errorf("Unexpected reauthorization %+v", auth)
+		t.FailNow()
+	}
+}
+
+func TestClient_GetPaymentsResources(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v2/payments/authorizations/0VF52814937998046": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "0VF52814937998046", "status": "CREATED", "amount": {"currency_code": "USD", "value": "10.99"}, "expiration_time": "2017-10-10T23:23:45Z"}`))
+		},
+		"GET /v2/payments/captures/2GG279541U471931P": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "2GG279541U471931P", "status": "COMPLETED", "final_capture": true, "invoice_id": "INVOICE-123"}`))
+		},
+		"GET /v2/payments/refunds/1JU08902781691411": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "1JU08902781691411", "status": "COMPLETED", "amount": {"currency_code": "USD", "value": "5.00"}, "note_to_payer": "Defective product"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get authorization
+	auth, err := client.GetAuthorizedPayment("0VF52814937998046")
+
+	if err != nil {
+		t.Errorf("Cannot get authorized payment: %v", err)
+		t.FailNow()
+	}
+
+	if auth.Status != "CREATED" || auth.Amount == nil || auth.Amount.Value != "10.99" || auth.ExpirationTime.Year() != 2017 {
+		t.Errorf("Unexpected authorized payment %+v", auth)
+		t.FailNow()
+	}
+
+	// Get capture
+	capture, err := client.GetCapturedPayment("2GG279541U471931P")
+
+	if err != nil {
+		t.Errorf("Cannot get captured payment: %v", err)
+		t.FailNow()
+	}
+
+	if capture.Status != "COMPLETED" || !capture.FinalCapture || capture.InvoiceID != "INVOICE-123" {
+		t.Errorf("Unexpected captured payment %+v", capture)
+		t.FailNow()
+	}
+
+	// Get refund
+	refund, err := client.GetPaymentRefund("1JU08902781691411")
+
+	if err != nil {
+		t.Errorf("Cannot get payment refund: %v", err)
+		t.FailNow()
+	}
+
+	if refund.Status != "COMPLETED" || refund.Amount == nil || refund.Amount.Value != "5.00" || refund.NoteToPayer != "Defective product" {
+		t.Errorf("Unexpected payment refund %+v", refund)
+		t.FailNow()
+	}
+}
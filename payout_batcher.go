@@ -0,0 +1,143 @@
+package gopaypal
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MaxPayoutItems is the maximum number of items PayPal accepts on a single payout batch
+const MaxPayoutItems = 15000
+
+// PayoutBatcher splits recipient lists into payout batches within the PayPal per batch limit. It
+// remembers every sender batch ID it hands out so a batch ID is never reused, since PayPal rejects
+// batches reusing a sender batch ID from the last 30 days. IDs used by previous runs can be
+// registered with MarkUsed. It is safe for concurrent use
+type PayoutBatcher struct {
+	// Header is the sender batch header copied into every batch
+	Header SenderBatchHeader
+
+	// MaxItems is the maximum number of items of a batch. Zero means MaxPayoutItems
+	MaxItems int
+
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+// NewPayoutBatcher returns a batcher creating batches with the given sender batch header
+func NewPayoutBatcher(header SenderBatchHeader) *PayoutBatcher {
+	return &PayoutBatcher{Header: header}
+}
+
+// MarkUsed registers sender batch IDs already used, e.g. by previous runs
+func (b *PayoutBatcher) MarkUsed(ids ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.used == nil {
+		b.used = map[string]bool{}
+	}
+
+	for _, id := range ids {
+		b.used[id] = true
+	}
+}
+
+// Used reports whether the sender batch ID was handed out or registered as used
+func (b *PayoutBatcher) Used(id string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.used[id]
+}
+
+// Split splits the items into payout batches of at most MaxItems items. A single batch keeps the
+// header sender batch ID, and several batches get it suffixed with their position, e.g. "ID-2".
+// Random IDs are generated when the header has none. Items with a repeated sender item ID and
+// already used batch IDs are rejected without handing out any batch ID
+func (b *PayoutBatcher) Split(items []PayoutItem) ([]Payout, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("payout without items")
+	}
+
+	size := b.MaxItems
+
+	if size <= 0 || size > MaxPayoutItems {
+		size = MaxPayoutItems
+	}
+
+	// Sender item IDs identify the items of a batch
+	seen := map[string]bool{}
+
+	for _, item := range items {
+		if item.SenderItemID == "" {
+			continue
+		}
+
+		if seen[item.SenderItemID] {
+			return nil, fmt.Errorf("repeated sender item ID %v", item.SenderItemID)
+		}
+
+		seen[item.SenderItemID] = true
+	}
+
+	count := (len(items) + size - 1) / size
+	payouts := make([]Payout, 0, count)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := 0; i < count; i++ {
+		header := b.Header
+		header.SenderBatchID = b.batchID(i, count)
+
+		if b.used[header.SenderBatchID] || batchIDTaken(payouts, header.SenderBatchID) {
+			return nil, fmt.Errorf("sender batch ID %v already used", header.SenderBatchID)
+		}
+
+		end := (i + 1) * size
+
+		if end > len(items) {
+			end = len(items)
+		}
+
+		payouts = append(payouts, Payout{
+			SenderBatchHeader: header,
+			Items:             items[i*size : end],
+		})
+	}
+
+	if b.used == nil {
+		b.used = map[string]bool{}
+	}
+
+	// Only hand out the batch IDs once every batch is valid
+	for _, payout := range payouts {
+		b.used[payout.SenderBatchHeader.SenderBatchID] = true
+	}
+
+	return payouts, nil
+}
+
+// batchID returns the sender batch ID of the batch at the given position
+func (b *PayoutBatcher) batchID(i, count int) string {
+	if b.Header.SenderBatchID == "" {
+		return CreateRequestID()
+	}
+
+	if count == 1 {
+		return b.Header.SenderBatchID
+	}
+
+	return fmt.Sprintf("%v-%v", b.Header.SenderBatchID, i+1)
+}
+
+// batchIDTaken reports whether one of the payouts has the given sender batch ID
+func batchIDTaken(payouts []Payout, id string) bool {
+	for _, payout := range payouts {
+		if payout.SenderBatchHeader.SenderBatchID == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package gopaypal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func payoutItems(n int) []PayoutItem {
+	items := []PayoutItem{}
+
+	for i := 0; i < n; i++ {
+		items = append(items, PayoutItem{
+			Receiver:     fmt.Sprintf("seller%v@example.com", i),
+			Amount:       Currency{Currency: "USD", Value: "1.00"},
+			SenderItemID: fmt.Sprint(i),
+		})
+	}
+
+	return items
+}
+
+func TestPayoutBatcher_Split(t *testing.T) {
+	batcher := NewPayoutBatcher(SenderBatchHeader{SenderBatchID: "batch", RecipientType: PayoutRecipientEmail})
+
+	payouts, err := batcher.Split(payoutItems(MaxPayoutItems + 1))
+
+	if err != nil {
+		t.Errorf("Cannot split payout: %v", err)
+		t.FailNow()
+	}
+
+	if len(payouts) != 2 || len(payouts[0].Items) != MaxPayoutItems || len(payouts[1].Items) != 1 {
+		t.Errorf("Unexpected payout batches %v", len(payouts))
+		t.FailNow()
+	}
+
+	if payouts[0].SenderBatchHeader.SenderBatchID != "batch-1" || payouts[1].SenderBatchHeader.RecipientType != PayoutRecipientEmail {
+		t.Errorf("Unexpected payout batch header %+v", payouts[0].SenderBatchHeader)
+		t.FailNow()
+	}
+
+	if !batcher.Used("batch-2") || batcher.Used("batch") {
+		t.Errorf("Unexpected used batch IDs")
+		t.FailNow()
+	}
+
+	// Batch IDs are never handed out twice
+	if _, err := batcher.Split(payoutItems(MaxPayoutItems + 1)); err == nil {
+		t.Errorf("Expected an error reusing a batch ID")
+		t.FailNow()
+	}
+
+	// A single batch keeps the header batch ID
+	payouts, err = batcher.Split(payoutItems(1))
+
+	if err != nil || payouts[0].SenderBatchHeader.SenderBatchID != "batch" {
+		t.Errorf("Unexpected single payout batch %+v: %v", payouts, err)
+		t.FailNow()
+	}
+}
+
+func TestPayoutBatcher_Errors(t *testing.T) {
+	batcher := NewPayoutBatcher(SenderBatchHeader{SenderBatchID: "previous"})
+	batcher.MarkUsed("previous")
+
+	if _, err := batcher.Split(payoutItems(1)); err == nil {
+		t.Errorf("Expected an error using a batch ID marked as used")
+	}
+
+	// Failed splits do not hand out batch IDs
+	batcher = NewPayoutBatcher(SenderBatchHeader{SenderBatchID: "batch"})
+	items := append(payoutItems(2), PayoutItem{Receiver: "other@example.com", SenderItemID: "1"})
+
+	if _, err := batcher.Split(items); err == nil || batcher.Used("batch") {
+		t.Errorf("Expected an error splitting repeated sender item IDs")
+	}
+
+	if _, err := batcher.Split(nil); err == nil {
+		t.Errorf("Expected an error splitting no items")
+	}
+
+	// Batch IDs are generated when missing
+	batcher = NewPayoutBatcher(SenderBatchHeader{})
+	batcher.MaxItems = 1
+
+	payouts, err := batcher.Split(payoutItems(2))
+
+	if err != nil {
+		t.Errorf("Cannot split payout: %v", err)
+		t.FailNow()
+	}
+
+	a, b := payouts[0].SenderBatchHeader.SenderBatchID, payouts[1].SenderBatchHeader.SenderBatchID
+
+	if a == "" || a == b || !batcher.Used(a) {
+		t.Errorf("Unexpected generated batch IDs %v %v", a, b)
+	}
+}
@@ -0,0 +1,306 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	PayoutRecipientEmail    = "EMAIL"
+	PayoutRecipientPhone    = "PHONE"
+	PayoutRecipientPayPalID = "PAYPAL_ID"
+
+	PayoutBatchStatusDenied     = "DENIED"
+	PayoutBatchStatusPending    = "PENDING"
+	PayoutBatchStatusProcessing = "PROCESSING"
+	PayoutBatchStatusSuccess    = "SUCCESS"
+	PayoutBatchStatusCanceled   = "CANCELED"
+
+	PayoutItemStatusSuccess   = "SUCCESS"
+	PayoutItemStatusFailed    = "FAILED"
+	PayoutItemStatusPending   = "PENDING"
+	PayoutItemStatusUnclaimed = "UNCLAIMED"
+	PayoutItemStatusReturned  = "RETURNED"
+	PayoutItemStatusOnHold    = "ONHOLD"
+	PayoutItemStatusBlocked   = "BLOCKED"
+	PayoutItemStatusRefunded  = "REFUNDED"
+	PayoutItemStatusReversed  = "REVERSED"
+)
+
+// Payout is a batch of payments sent to PayPal accounts. PayPal rejects batches reusing a sender
+// batch ID from the last 30 days, and PayoutBatcher splits large recipient lists into batches
+type Payout struct {
+	SenderBatchHeader SenderBatchHeader `json:"sender_batch_header"`
+	Items             []PayoutItem      `json:"items"`
+}
+
+// SenderBatchHeader identifies a payout batch and holds the email sent to its recipients. The
+// recipient type applies to the items without one
+type SenderBatchHeader struct {
+	SenderBatchID string `json:"sender_batch_id,omitempty"`
+	RecipientType string `json:"recipient_type,omitempty"`
+	EmailSubject  string `json:"email_subject,omitempty"`
+	EmailMessage  string `json:"email_message,omitempty"`
+}
+
+// PayoutItem is a payment to a single recipient, identified by the receiver email, phone or PayPal
+// ID depending on the recipient type
+type PayoutItem struct {
+	RecipientType string   `json:"recipient_type,omitempty"`
+	Amount        Currency `json:"amount"`
+	Note          string   `json:"note,omitempty"`
+	Receiver      string   `json:"receiver"`
+	SenderItemID  string   `json:"sender_item_id,omitempty"`
+}
+
+// PayoutBatch is the state of a payout batch along with a page of its items
+type PayoutBatch struct {
+	BatchHeader PayoutBatchHeader  `json:"batch_header"`
+	Items       []PayoutItemDetail `json:"items,omitempty"`
+	TotalItems  int                `json:"total_items,omitempty"`
+	TotalPages  int                `json:"total_pages,omitempty"`
+	Links       Links              `json:"links,omitempty"`
+	RequestID   string             `json:"-"`
+}
+
+// PayoutBatchHeader holds the status and the totals of a payout batch
+type PayoutBatchHeader struct {
+	PayoutBatchID     string            `json:"payout_batch_id"`
+	BatchStatus       string            `json:"batch_status"`
+	TimeCreated       string            `json:"time_created,omitempty"`
+	TimeCompleted     string            `json:"time_completed,omitempty"`
+	SenderBatchHeader SenderBatchHeader `json:"sender_batch_header"`
+	Amount            *Currency         `json:"amount,omitempty"`
+	Fees              *Currency         `json:"fees,omitempty"`
+}
+
+// PayoutItemDetail is the state of a payout item. Errors holds the reason of failed items
+type PayoutItemDetail struct {
+	PayoutItemID      string       `json:"payout_item_id"`
+	TransactionID     string       `json:"transaction_id,omitempty"`
+	ActivityID        string       `json:"activity_id,omitempty"`
+	TransactionStatus string       `json:"transaction_status,omitempty"`
+	PayoutItemFee     *Currency    `json:"payout_item_fee,omitempty"`
+	PayoutBatchID     string       `json:"payout_batch_id,omitempty"`
+	SenderBatchID     string       `json:"sender_batch_id,omitempty"`
+	PayoutItem        PayoutItem   `json:"payout_item"`
+	TimeProcessed     string       `json:"time_processed,omitempty"`
+	Errors            *PayPalError `json:"errors,omitempty"`
+	Links             Links        `json:"links,omitempty"`
+	RequestID         string       `json:"-"`
+}
+
+// PayoutBatchParams paginates the items returned by GetPayoutBatch. Zero values are not sent
+type PayoutBatchParams struct {
+	Page          int
+	PageSize      int
+	TotalRequired bool
+}
+
+// PayoutItemIterator iterates over the items of a payout batch, fetching pages as needed
+//
+//	it := client.IteratePayoutItems(ctx, batchID, params)
+//
+//	for it.Next() {
+//		item := it.Item()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PayoutItemIterator struct {
+	pager
+	page []PayoutItemDetail
+}
+
+// Query returns the batch parameters encoded as an URL query
+func (p PayoutBatchParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	if p.TotalRequired {
+		query.Set("total_required", "true")
+	}
+
+	return query
+}
+
+// CreatePayout creates a payout batch. The batch is processed asynchronously, its status is
+// followed with GetPayoutBatch
+func (c Client) CreatePayout(payout Payout) (*PayoutBatch, error) {
+	return c.CreatePayoutWithContext(context.Background(), payout)
+}
+
+// CreatePayoutWithContext creates a payout batch using the given context
+func (c Client) CreatePayoutWithContext(ctx context.Context, payout Payout) (*PayoutBatch, error) {
+	// Hold batch response
+	d := PayoutBatch{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, PayoutsURL, &payout, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// CreatePayouts creates the given payout batches in order, stopping at the first failure. The
+// batches created so far are returned along with the error, so the remaining payouts can be sent
+// again later
+func (c Client) CreatePayouts(payouts []Payout) ([]*PayoutBatch, error) {
+	return c.CreatePayoutsWithContext(context.Background(), payouts)
+}
+
+// CreatePayoutsWithContext creates the given payout batches in order using the given context. When
+// the context carries an idempotency key (see ContextWithRequestID), every batch is sent with the
+// key followed by its position, e.g. "KEY-2", so PayPal does not take the batches for retries of the
+// first one. Otherwise a key is generated for each batch
+func (c Client) CreatePayoutsWithContext(ctx context.Context, payouts []Payout) ([]*PayoutBatch, error) {
+	batches := []*PayoutBatch{}
+	key := RequestIDFromContext(ctx)
+
+	for i, payout := range payouts {
+		// Give every batch its own idempotency key
+		batchCtx := ctx
+
+		if key != "" {
+			batchCtx = ContextWithRequestID(ctx, fmt.Sprintf("%v-%v", key, i+1))
+		}
+
+		batch, err := c.CreatePayoutWithContext(batchCtx, payout)
+
+		if err != nil {
+			return batches, fmt.Errorf("cannot create payout batch %v: %w", payout.SenderBatchHeader.SenderBatchID, err)
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// GetPayoutBatch gets the status of the payout batch with the given ID along with a page of its items
+func (c Client) GetPayoutBatch(batchID string, params PayoutBatchParams) (*PayoutBatch, error) {
+	return c.GetPayoutBatchWithContext(context.Background(), batchID, params)
+}
+
+// GetPayoutBatchWithContext gets the status of the payout batch with the given ID along with a page
+// of its items using the given context
+func (c Client) GetPayoutBatchWithContext(ctx context.Context, batchID string, params PayoutBatchParams) (*PayoutBatch, error) {
+	return c.getPayoutBatch(ctx, payoutBatchEndpoint(batchID, params))
+}
+
+// IteratePayoutItems returns an iterator over every item of the payout batch with the given ID
+// starting at the given page
+func (c Client) IteratePayoutItems(ctx context.Context, batchID string, params PayoutBatchParams) *PayoutItemIterator {
+	it := &PayoutItemIterator{}
+
+	it.pager = newPager(ctx, payoutBatchEndpoint(batchID, params), func(ctx context.Context, endpoint string) (int, string, error) {
+		batch, err := c.getPayoutBatch(ctx, endpoint)
+
+		if err != nil {
+			return 0, "", err
+		}
+
+		it.page = batch.Items
+
+		return len(batch.Items), nextLink(batch.Links), nil
+	})
+
+	return it
+}
+
+// GetPayoutItem gets the status of the payout item with the given ID
+func (c Client) GetPayoutItem(itemID string) (*PayoutItemDetail, error) {
+	return c.GetPayoutItemWithContext(context.Background(), itemID)
+}
+
+// GetPayoutItemWithContext gets the status of the payout item with the given ID using the given context
+func (c Client) GetPayoutItemWithContext(ctx context.Context, itemID string) (*PayoutItemDetail, error) {
+	return c.payoutItemRequest(ctx, http.MethodGet, fmt.Sprintf(PayoutItemURL, itemID))
+}
+
+// CancelPayoutItem cancels the unclaimed payout item with the given ID, returning its amount to the
+// sender
+func (c Client) CancelPayoutItem(itemID string) (*PayoutItemDetail, error) {
+	return c.CancelPayoutItemWithContext(context.Background(), itemID)
+}
+
+// CancelPayoutItemWithContext cancels the unclaimed payout item with the given ID using the given context
+func (c Client) CancelPayoutItemWithContext(ctx context.Context, itemID string) (*PayoutItemDetail, error) {
+	return c.payoutItemRequest(ctx, http.MethodPost, fmt.Sprintf(PayoutItemCancelURL, itemID))
+}
+
+// Next advances the iterator to the next item. It returns false when there are no more items or
+// an error happened
+func (it *PayoutItemIterator) Next() bool {
+	return it.next()
+}
+
+// Item returns the current payout item
+func (it *PayoutItemIterator) Item() *PayoutItemDetail {
+	i, ok := it.current()
+
+	if !ok {
+		return nil
+	}
+
+	return &it.page[i]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *PayoutItemIterator) Err() error {
+	return it.err
+}
+
+// getPayoutBatch gets the payout batch page at the given endpoint
+func (c Client) getPayoutBatch(ctx context.Context, endpoint string) (*PayoutBatch, error) {
+	// Hold batch response
+	d := PayoutBatch{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, endpoint, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// payoutItemRequest runs a payout item request and returns the resulting item
+func (c Client) payoutItemRequest(ctx context.Context, method, endpoint string) (*PayoutItemDetail, error) {
+	// Hold item response
+	d := PayoutItemDetail{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, nil, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// payoutBatchEndpoint returns the payout batch endpoint with the given parameters
+func payoutBatchEndpoint(batchID string, params PayoutBatchParams) string {
+	endpoint := fmt.Sprintf(PayoutURL, batchID)
+
+	if query := params.Query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	return endpoint
+}
@@ -0,0 +1,284 @@
+package gopaypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_CreatePayout(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/payments/payouts": func(w http.ResponseWriter, r *http.Request) {
+			payout := Payout{}
+
+			if err := json.NewDecoder(r.Body).Decode(&payout); err != nil || payout.SenderBatchHeader.SenderBatchID != "Payouts_2018_100007" || len(payout.Items) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if item := payout.Items[0]; item.Receiver != "seller1@example.com" || item.Amount.Value != "9.87" || item.SenderItemID != "1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"batch_header": {"payout_batch_id": "5UXD2E8A7EBQJ", "batch_status": "PENDING", "sender_batch_header": {"sender_batch_id": "Payouts_2018_100007"}}}`))
+		},
+		"GET /v1/payments/payouts/5UXD2E8A7EBQJ": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+
+			if q.Get("page") != "2" || q.Get("page_size") != "1" || q.Get("total_required") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{
+				"batch_header": {"payout_batch_id": "5UXD2E8A7EBQJ", "batch_status": "SUCCESS", "amount": {"currency": "USD", "value": "9.87"}},
+				"items": [{"payout_item_id": "8AELMXH8UB2P8", "transaction_status": "SUCCESS", "payout_item": {"receiver": "seller1@example.com", "amount": {"currency": "USD", "value": "9.87"}}}],
+				"total_items": 2,
+				"total_pages": 2
+			}`))
+		},
+		"GET /v1/payments/payouts-item/8AELMXH8UB2P8": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"payout_item_id": "8AELMXH8UB2P8", "payout_batch_id": "5UXD2E8A7EBQJ", "transaction_status": "SUCCESS", "payout_item_fee": {"currency": "USD", "value": "0.25"}}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Create payout batch
+	batch, err := client.CreatePayout(Payout{
+		SenderBatchHeader: SenderBatchHeader{SenderBatchID: "Payouts_2018_100007", RecipientType: PayoutRecipientEmail},
+		Items: []PayoutItem{
+			{Receiver: "seller1@example.com", Amount: Currency{Currency: "USD", Value: "9.87"}, SenderItemID: "1"},
+		},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create payout: %v", err)
+		t.FailNow()
+	}
+
+	if batch.BatchHeader.PayoutBatchID != "5UXD2E8A7EBQJ" || batch.BatchHeader.BatchStatus != PayoutBatchStatusPending || batch.RequestID == "" {
+		t.Errorf("Unexpected created payout batch %+v", batch)
+		t.FailNow()
+	}
+
+	// Get a page of the batch items
+	batch, err = client.GetPayoutBatch(batch.BatchHeader.PayoutBatchID, PayoutBatchParams{Page: 2, PageSize: 1, TotalRequired: true})
+
+	if err != nil {
+		t.Errorf("Cannot get payout batch: %v", err)
+		t.FailNow()
+	}
+
+	if batch.BatchHeader.BatchStatus != PayoutBatchStatusSuccess || batch.TotalItems != 2 || len(batch.Items) != 1 || batch.Items[0].PayoutItem.Receiver != "seller1@example.com" {
+		t.Errorf("Unexpected payout batch %+v", batch)
+		t.FailNow()
+	}
+
+	// Get payout item
+	item, err := client.GetPayoutItem(batch.Items[0].PayoutItemID)
+
+	if err != nil {
+		t.Errorf("Cannot get payout item: %v", err)
+		t.FailNow()
+	}
+
+	if item.TransactionStatus != PayoutItemStatusSuccess || item.PayoutBatchID != "5UXD2E8A7EBQJ" || item.PayoutItemFee == nil || item.PayoutItemFee.Value != "0.25" {
+		t.Errorf("Unexpected payout item %+v", item)
+		t.FailNow()
+	}
+}
+
+func TestPayoutBatchParams_Query(t *testing.T) {
+	if query := (PayoutBatchParams{}).Query(); len(query) != 0 {
+		t.Errorf("Unexpected empty batch parameters query %v", query.Encode())
+		t.FailNow()
+	}
+
+	query := PayoutBatchParams{Page: 3, PageSize: 100, TotalRequired: true}.Query().Encode()
+
+	if query != "page=3&page_size=100&total_required=true" {
+		t.Errorf("Unexpected batch parameters query. Got %v expected %v", query, "page=3&page_size=100&total_required=true")
+		t.FailNow()
+	}
+}
+
+func TestClient_CreatePayouts(t *testing.T) {
+	batches := []Payout{}
+	keys := []string{}
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/payments/payouts": func(w http.ResponseWriter, r *http.Request) {
+			payout := Payout{}
+
+			if err := json.NewDecoder(r.Body).Decode(&payout); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			// PayPal rejects repeated sender batch IDs
+			for _, b := range batches {
+				if b.SenderBatchHeader.SenderBatchID == payout.SenderBatchHeader.SenderBatchID {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"name": "USER_BUSINESS_ERROR", "message": "Batch with given sender_batch_id already exists"}`))
+					return
+				}
+			}
+
+			batches = append(batches, payout)
+			keys = append(keys, r.Header.Get(RequestIDHeader))
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"batch_header": {"payout_batch_id": "5UXD2E8A7EBQJ", "batch_status": "PENDING", "sender_batch_header": {"sender_batch_id": "` + payout.SenderBatchHeader.SenderBatchID + `"}}}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	batcher := NewPayoutBatcher(SenderBatchHeader{SenderBatchID: "Payouts_2018_100007", EmailSubject: "You have a payout!"})
+	batcher.MaxItems = 2
+
+	payouts, err := batcher.Split([]PayoutItem{
+		{RecipientType: PayoutRecipientEmail, Receiver: "seller1@example.com", Amount: Currency{Currency: "USD", Value: "9.87"}, SenderItemID: "1"},
+		{RecipientType: PayoutRecipientPhone, Receiver: "408-555-0100", Amount: Currency{Currency: "USD", Value: "112.34"}, SenderItemID: "2"},
+		{RecipientType: PayoutRecipientPayPalID, Receiver: "G83JXTJ5EHCQ2", Amount: Currency{Currency: "USD", Value: "5.32"}, SenderItemID: "3"},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot split payout: %v", err)
+		t.FailNow()
+	}
+
+	created, err := client.CreatePayouts(payouts)
+
+	if err != nil {
+		t.Errorf("Cannot create payouts: %v", err)
+		t.FailNow()
+	}
+
+	if len(created) != 2 || created[1].BatchHeader.SenderBatchHeader.SenderBatchID != "Payouts_2018_100007-2" || created[0].RequestID == "" {
+		t.Errorf("Unexpected created payout batches %+v", created)
+		t.FailNow()
+	}
+
+	if len(batches[0].Items) != 2 || len(batches[1].Items) != 1 || batches[1].SenderBatchHeader.EmailSubject != "You have a payout!" {
+		t.Errorf("Unexpected sent payout batches %+v", batches)
+		t.FailNow()
+	}
+
+	if keys[0] == "" || keys[0] == keys[1] {
+		t.Errorf("Payout batches sent with the same idempotency key %v", keys)
+		t.FailNow()
+	}
+
+	// Caller keys are suffixed with the batch position
+	batches, keys = nil, nil
+
+	batcher = NewPayoutBatcher(SenderBatchHeader{SenderBatchID: "Payouts_2018_100009"})
+	batcher.MaxItems = 1
+
+	payouts, err = batcher.Split([]PayoutItem{
+		{Receiver: "seller1@example.com", Amount: Currency{Currency: "USD", Value: "1.00"}},
+		{Receiver: "seller2@example.com", Amount: Currency{Currency: "USD", Value: "1.00"}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot split payout: %v", err)
+		t.FailNow()
+	}
+
+	created, err = client.CreatePayoutsWithContext(ContextWithRequestID(context.Background(), "k"), payouts)
+
+	if err != nil {
+		t.Errorf("Cannot create payouts: %v", err)
+		t.FailNow()
+	}
+
+	if len(keys) != 2 || keys[0] != "k-1" || keys[1] != "k-2" || created[1].RequestID != "k-2" {
+		t.Errorf("Unexpected payout batch idempotency keys %v", keys)
+		t.FailNow()
+	}
+
+	// Creation stops at the first failure
+	created, err = client.CreatePayouts(append([]Payout{{SenderBatchHeader: SenderBatchHeader{SenderBatchID: "Payouts_2018_100008"}}}, payouts...))
+
+	if !IsValidation(err) || len(created) != 1 {
+		t.Errorf("Unexpected payout creation result %+v: %v", created, err)
+		t.FailNow()
+	}
+}
+
+func TestClient_IteratePayoutItems(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/payments/payouts/5UXD2E8A7EBQJ": func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("page") {
+			case "":
+				w.Write([]byte(`{
+					"batch_header": {"payout_batch_id": "5UXD2E8A7EBQJ", "batch_status": "SUCCESS"},
+					"items": [{"payout_item_id": "8AELMXH8UB2P8", "transaction_status": "SUCCESS"}, {"payout_item_id": "9BFMNYJ9VC3Q9", "transaction_status": "UNCLAIMED"}],
+					"links": [{"href": "https://api.sandbox.paypal.com/v1/payments/payouts/5UXD2E8A7EBQJ?page_size=2&page=2", "rel": "next", "method": "GET"}]
+				}`))
+			case "2":
+				w.Write([]byte(`{
+					"batch_header": {"payout_batch_id": "5UXD2E8A7EBQJ", "batch_status": "SUCCESS"},
+					"items": [{"payout_item_id": "7CGNOZK7WD4R7", "transaction_status": "FAILED", "errors": {"name": "RECEIVER_UNREGISTERED", "message": "Receiver is unregistered"}}]
+				}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		},
+		"POST /v1/payments/payouts-item/9BFMNYJ9VC3Q9/cancel": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"payout_item_id": "9BFMNYJ9VC3Q9", "transaction_status": "RETURNED"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	it := client.IteratePayoutItems(context.Background(), "5UXD2E8A7EBQJ", PayoutBatchParams{PageSize: 2})
+	unclaimed := []string{}
+	failed := []*PayPalError{}
+
+	for it.Next() {
+		switch item := it.Item(); item.TransactionStatus {
+		case PayoutItemStatusUnclaimed:
+			unclaimed = append(unclaimed, item.PayoutItemID)
+		case PayoutItemStatusFailed:
+			failed = append(failed, item.Errors)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Errorf("Cannot iterate payout items: %v", err)
+		t.FailNow()
+	}
+
+	if len(unclaimed) != 1 || len(failed) != 1 || failed[0].Name != "RECEIVER_UNREGISTERED" {
+		t.Errorf("Unexpected payout items %v %+v", unclaimed, failed)
+		t.FailNow()
+	}
+
+	// Unclaimed items can be cancelled
+	item, err := client.CancelPayoutItem(unclaimed[0])
+
+	if err != nil {
+		t.Errorf("Cannot cancel payout item: %v", err)
+		t.FailNow()
+	}
+
+	if item.TransactionStatus != PayoutItemStatusReturned {
+		t.Errorf("Unexpected cancelled payout item status. Got %v expected %v", item.TransactionStatus, PayoutItemStatusReturned)
+		t.FailNow()
+	}
+}
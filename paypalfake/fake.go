@@ -0,0 +1,83 @@
+// Package paypalfake provides in-memory fakes of the gopaypal client interfaces. Program a fake by
+// setting the Func fields of the calls you expect and check the recorded calls afterwards
+//
+//	payments := &paypalfake.Payments{
+//		CreatePaymentFunc: func(ctx context.Context, p gopaypal.Payment) (*gopaypal.PaymentResponse, error) {
+//			return &gopaypal.PaymentResponse{ID: "PAY-1", State: "created"}, nil
+//		},
+//	}
+//
+//	checkout := NewCheckout(payments)
+//
+//	if len(payments.Calls()) != 1 {
+//		...
+//	}
+package paypalfake
+
+//go:generate go run ../internal/fakegen -in ../api.go -out fakes.go
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnexpectedCall is returned by the fakes when a call has no Func field set
+var ErrUnexpectedCall = errors.New("unexpected call")
+
+// Call is a call recorded by a fake. Context arguments are not recorded
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder records the calls made to a fake. It is safe for concurrent use
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record records a call to the given method with the given arguments
+func (r *Recorder) Record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{
+		Method: method,
+		Args:   args,
+	})
+}
+
+// Calls returns the recorded calls in order
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to the given method in order
+func (r *Recorder) CallsTo(method string) []Call {
+	calls := []Call{}
+
+	for _, c := range r.Calls() {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets the recorded calls
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+// unexpectedCall returns the error of a call to the given fake method without a Func field set
+func unexpectedCall(method string) error {
+	return fmt.Errorf("%w to %v", ErrUnexpectedCall, method)
+}
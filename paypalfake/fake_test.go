@@ -0,0 +1,72 @@
+package paypalfake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joseluis2g/gopaypal"
+)
+
+// checkout is a sample service depending on the narrow payments interface
+func checkout(api gopaypal.PaymentsAPI, total string) (string, error) {
+	res, err := api.CreatePayment(gopaypal.Payment{
+		Intent: "sale",
+		Transactions: []gopaypal.Transaction{
+			{Amount: gopaypal.Amount{Total: total, Currency: "EUR"}},
+		},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return res.ID, nil
+}
+
+func TestPayments_CreatePayment(t *testing.T) {
+	// Program the fake with a canned response
+	fake := &Payments{
+		CreatePaymentFunc: func(ctx context.Context, p gopaypal.Payment) (*gopaypal.PaymentResponse, error) {
+			return &gopaypal.PaymentResponse{ID: "PAY-1", State: "created"}, nil
+		},
+	}
+
+	id, err := checkout(fake, "10")
+
+	if err != nil || id != "PAY-1" {
+		t.Errorf("Unexpected checkout result %v, %v", id, err)
+		t.FailNow()
+	}
+
+	// Check the recorded calls
+	calls := fake.CallsTo("CreatePayment")
+
+	if len(calls) != 1 || calls[0].Args[0].(gopaypal.Payment).Transactions[0].Amount.Total != "10" {
+		t.Errorf("Unexpected recorded calls %+v", fake.Calls())
+		t.FailNow()
+	}
+
+	// Calls not programmed fail
+	if _, err := fake.ExecutePayment("PAY-1", "PAYER-1"); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("Unexpected error. Got %v expected %v", err, ErrUnexpectedCall)
+		t.FailNow()
+	}
+}
+
+func TestAPI_Embedded(t *testing.T) {
+	fake := &API{}
+
+	fake.Orders.GetOrderFunc = func(ctx context.Context, id string) (*gopaypal.Order, error) {
+		return &gopaypal.Order{ID: id, Status: "APPROVED"}, nil
+	}
+
+	var api gopaypal.API = fake
+
+	order, err := api.GetOrder("5O190127TN364715T")
+
+	if err != nil || order.Status != "APPROVED" {
+		t.Errorf("Unexpected order %+v, %v", order, err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,373 @@
+package paypaltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the redacted values on the recorded cassettes
+const Redacted = "REDACTED"
+
+// ErrNoInteraction is returned by a replaying recorder when the cassette holds no interaction
+// matching the request
+var ErrNoInteraction = errors.New("paypaltest: no recorded interaction")
+
+// Mode is the working mode of a recorder
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of an existing cassette and never hits the network
+	ModeReplay Mode = iota
+
+	// ModeRecord sends every request through the transport and records the interactions
+	ModeRecord
+
+	// ModeAuto replays the cassette if it exists and records it otherwise
+	ModeAuto
+)
+
+var (
+	// DefaultRedactedHeaders are the headers redacted on the recorded requests and responses
+	DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+	// DefaultRedactedFields are the JSON and form fields redacted on the recorded bodies
+	DefaultRedactedFields = []string{"access_token", "refresh_token", "id_token", "client_secret", "code"}
+)
+
+// Cassette holds the interactions recorded by a recorder
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a redacted HTTP request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a redacted HTTP response
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording the exchanges with PayPal on a cassette file and
+// replaying them afterwards. Credentials and tokens are redacted before recording. Requests are
+// matched on their method, path, query and normalized body, and identical requests are replayed
+// in the recorded order. It is safe for concurrent use
+//
+//	rec, err := paypaltest.NewRecorder("testdata/create_payment.json", paypaltest.ModeAuto, nil)
+//	...
+//	defer rec.Save()
+//
+//	client := gopaypal.NewClient(clientID, secret, gopaypal.SandBoxURL, gopaypal.WithTransport(rec))
+type Recorder struct {
+	// RedactHeaders and RedactFields are the headers and body fields redacted on record
+	RedactHeaders []string
+	RedactFields  []string
+
+	mu        sync.Mutex
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	cassette  Cassette
+	played    map[string]int
+}
+
+// NewRecorder creates a recorder for the cassette file on the given path. Recorded requests are
+// sent through the given transport, or http.DefaultTransport when nil. Replaying recorders load the
+// cassette on creation
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		RedactHeaders: DefaultRedactedHeaders,
+		RedactFields:  DefaultRedactedFields,
+		path:          path,
+		mode:          mode,
+		transport:     transport,
+		played:        map[string]int{},
+	}
+
+	if mode == ModeAuto {
+		r.mode = ModeRecord
+
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode != ModeReplay {
+		return r, nil
+	}
+
+	// Load cassette
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		return nil, fmt.Errorf("paypaltest: invalid cassette %v: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// Recording reports whether the recorder is recording interactions instead of replaying them
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+// RoundTrip replays or records the given request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Read request body
+	body := []byte{}
+
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = b
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    normalizeURL(req.URL),
+		Header: r.redactHeader(req.Header),
+		Body:   r.redactBody(body, req.Header.Get("Content-Type")),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	// Send request through the transport
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	res, err := r.transport.RoundTrip(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     r.redactHeader(res.Header),
+			Body:       r.redactBody(b, res.Header.Get("Content-Type")),
+		},
+	})
+
+	return res, nil
+}
+
+// Save writes the recorded cassette to its file. Replaying recorders do not write anything
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(&r.cassette, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, b, 0644)
+}
+
+// replay returns the next recorded response matching the request
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := recorded.key()
+	matches := []Interaction{}
+
+	for _, i := range r.cassette.Interactions {
+		if i.Request.key() == key {
+			matches = append(matches, i)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w for %v %v", ErrNoInteraction, recorded.Method, recorded.URL)
+	}
+
+	// Replay identical requests in order, repeating the last response once exhausted
+	n := r.played[key]
+
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+
+	r.played[key]++
+
+	res := matches[n].Response
+
+	header := http.Header{}
+
+	for k, v := range res.Header {
+		header[k] = v
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+		StatusCode:    res.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(res.Body)),
+		ContentLength: int64(len(res.Body)),
+		Request:       req,
+	}, nil
+}
+
+// redactHeader returns a copy of the header with the redacted headers replaced
+func (r *Recorder) redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+
+	for _, name := range r.RedactHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, Redacted)
+		}
+	}
+
+	return out
+}
+
+// redactBody returns the normalized body with the redacted fields replaced. JSON bodies are
+// compacted with sorted keys so equivalent bodies match on replay. Form bodies, sent with the
+// application/x-www-form-urlencoded content type, are sorted only when a field is redacted. Any
+// other body is kept as is
+func (r *Recorder) redactBody(b []byte, contentType string) string {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return ""
+	}
+
+	// Redact JSON body
+	var v interface{}
+
+	if err := json.Unmarshal(b, &v); err == nil {
+		out, err := json.Marshal(r.redactJSON(v))
+
+		if err == nil {
+			return string(out)
+		}
+	}
+
+	// Redact form body
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return string(b)
+	}
+
+	form, err := url.ParseQuery(string(b))
+
+	if err != nil {
+		return string(b)
+	}
+
+	redacted := false
+
+	for _, name := range r.RedactFields {
+		if _, ok := form[name]; ok {
+			form.Set(name, Redacted)
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return string(b)
+	}
+
+	return form.Encode()
+}
+
+// redactJSON replaces the redacted fields of the decoded JSON value
+func (r *Recorder) redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = r.redactJSON(e)
+
+			for _, name := range r.RedactFields {
+				if k == name {
+					t[k] = Redacted
+				}
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = r.redactJSON(e)
+		}
+	}
+
+	return v
+}
+
+// key returns the replay key of the request
+func (r RecordedRequest) key() string {
+	return r.Method + " " + r.URL + " " + r.Body
+}
+
+// normalizeURL returns the path and sorted query of the URL, so cassettes recorded against the
+// sandbox replay against any base URL
+func normalizeURL(u *url.URL) string {
+	// The sandbox base URL ends with a slash, so paths may start with two
+	p := "/" + strings.TrimLeft(u.Path, "/")
+
+	if u.RawQuery == "" {
+		return p
+	}
+
+	return p + "?" + u.Query().Encode()
+}
@@ -0,0 +1,144 @@
+package paypaltest
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joseluis2g/gopaypal"
+)
+
+func TestRecorder_RecordReplay(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "payment.json")
+
+	// Record a payment flow against the fake server
+	rec, err := NewRecorder(path, ModeAuto, nil)
+
+	if err != nil {
+		t.Errorf("Cannot create recorder: %v", err)
+		t.FailNow()
+	}
+
+	if !rec.Recording() {
+		t.Error("Recorder replaying a missing cassette")
+		t.FailNow()
+	}
+
+	client := srv.Client(gopaypal.WithTransport(rec))
+
+	res, err := client.CreatePayment(testPayment("sale"))
+
+	if err != nil {
+		t.Errorf("Cannot create payment: %v", err)
+		t.FailNow()
+	}
+
+	srv.ApprovePayment(res.ID, "PAYER-1")
+
+	if _, err := client.ExecutePayment(res.ID, "PAYER-1"); err != nil {
+		t.Errorf("Cannot execute payment: %v", err)
+		t.FailNow()
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Errorf("Cannot save cassette: %v", err)
+		t.FailNow()
+	}
+
+	// Check credentials and tokens are redacted
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		t.Errorf("Cannot read cassette: %v", err)
+		t.FailNow()
+	}
+
+	for _, secret := range []string{srv.Secret, "A21AA", "Basic ", "Bearer "} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("Cassette contains %q", secret)
+			t.FailNow()
+		}
+	}
+
+	// Replay the flow against an unreachable server
+	srv.Close()
+
+	rec, err = NewRecorder(path, ModeAuto, nil)
+
+	if err != nil {
+		t.Errorf("Cannot create replaying recorder: %v", err)
+		t.FailNow()
+	}
+
+	if rec.Recording() {
+		t.Error("Recorder recording an existing cassette")
+		t.FailNow()
+	}
+
+	client = gopaypal.NewClient(ClientID, Secret, "https://api.sandbox.paypal.com/", gopaypal.WithTransport(rec))
+
+	replayed, err := client.CreatePayment(testPayment("sale"))
+
+	if err != nil {
+		t.Errorf("Cannot replay payment creation: %v", err)
+		t.FailNow()
+	}
+
+	if replayed.ID != res.ID {
+		t.Errorf("Unexpected replayed payment ID. Got %v expected %v", replayed.ID, res.ID)
+		t.FailNow()
+	}
+
+	executed, err := client.ExecutePayment(res.ID, "PAYER-1")
+
+	if err != nil {
+		t.Errorf("Cannot replay payment execution: %v", err)
+		t.FailNow()
+	}
+
+	if executed.State != "approved" {
+		t.Errorf("Unexpected replayed payment state. Got %v expected %v", executed.State, "approved")
+		t.FailNow()
+	}
+
+	// Requests not recorded fail
+	if _, err := client.ExecutePayment(res.ID, "PAYER-2"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Unexpected error replaying an unknown request: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestRecorder_RedactBody(t *testing.T) {
+	rec := &Recorder{
+		RedactFields: DefaultRedactedFields,
+	}
+
+	form := "application/x-www-form-urlencoded"
+
+	tests := []struct {
+		body        string
+		contentType string
+		expected    string
+	}{
+		{`{"b": 1, "access_token": "A21AA", "a": {"refresh_token": "R23AA"}}`, "application/json", `{"a":{"refresh_token":"REDACTED"},"access_token":"REDACTED","b":1}`},
+		{"grant_type=authorization_code&code=C21AA&redirect_uri=x", form, "code=REDACTED&grant_type=authorization_code&redirect_uri=x"},
+		{"grant_type=authorization_code&code=C21AA", form + "; charset=utf-8", "code=REDACTED&grant_type=authorization_code"},
+		{"grant_type=client_credentials&scope=b+a", form, "grant_type=client_credentials&scope=b+a"},
+		{"code=C21AA", "text/plain", "code=C21AA"},
+		{`<a href="/x?code=1&b=2">x</a>`, "text/html", `<a href="/x?code=1&b=2">x</a>`},
+		{"QUJD==", "", "QUJD=="},
+		{"  ", form, ""},
+	}
+
+	for _, test := range tests {
+		if got := rec.redactBody([]byte(test.body), test.contentType); got != test.expected {
+			t.Errorf("Unexpected redacted body. Got %v expected %v", got, test.expected)
+			t.FailNow()
+		}
+	}
+}
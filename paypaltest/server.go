@@ -0,0 +1,722 @@
+// Package paypaltest provides an in-process stand-in of the PayPal REST API for tests. The server
+// issues OAuth2 tokens, creates, executes and looks up v1 payments and serves the identity token
+// service and user info endpoints, so code using gopaypal can be tested end to end without network
+//
+//	srv := paypaltest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//
+//	payment, err := client.CreatePayment(p)
+//	...
+//	srv.ApprovePayment(payment.ID, "PAYER-1")
+//
+//	executed, err := client.ExecutePayment(payment.ID, "PAYER-1")
+package paypaltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joseluis2g/gopaypal"
+)
+
+const (
+	// ClientID is the client ID accepted by a new server
+	ClientID = "paypaltest-client"
+
+	// Secret is the secret key accepted by a new server
+	Secret = "paypaltest-secret"
+
+	// DebugIDHeader is the header carrying the debug ID of the error responses
+	DebugIDHeader = "Paypal-Debug-Id"
+
+	appID                = "APP-80W284485P519543T"
+	identityTokenSeconds = 28800
+)
+
+// Server is a fake PayPal server. It is safe for concurrent use
+type Server struct {
+	*httptest.Server
+
+	// ClientID and Secret are the application credentials accepted by the server
+	ClientID string
+	Secret   string
+
+	// TokenLifetime is the lifetime of the issued OAuth2 tokens
+	TokenLifetime time.Duration
+
+	mu        sync.Mutex
+	seq       int
+	tokens    map[string]time.Time
+	payments  map[string]*payment
+	codes     map[string]gopaypal.IdentityUserInfoResponse
+	refresh   map[string]gopaypal.IdentityUserInfoResponse
+	identity  map[string]gopaypal.IdentityUserInfoResponse
+	responses map[string]*httptest.ResponseRecorder
+	failures  []*failure
+}
+
+// payment is a payment held by the server
+type payment struct {
+	gopaypal.PaymentResponse
+
+	approvedBy string
+}
+
+// failure is an injected error response
+type failure struct {
+	method string
+	path   string
+	times  int
+	status int
+	err    gopaypal.PayPalError
+}
+
+// NewServer starts and returns a fake PayPal server. The caller should call Close when finished
+func NewServer() *Server {
+	s := &Server{
+		ClientID:      ClientID,
+		Secret:        Secret,
+		TokenLifetime: 9 * time.Hour,
+		tokens:        map[string]time.Time{},
+		payments:      map[string]*payment{},
+		codes:         map[string]gopaypal.IdentityUserInfoResponse{},
+		refresh:       map[string]gopaypal.IdentityUserInfoResponse{},
+		identity:      map[string]gopaypal.IdentityUserInfoResponse{},
+		responses:     map[string]*httptest.ResponseRecorder{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// Client returns a gopaypal client using the server credentials and URL
+func (s *Server) Client(opts ...gopaypal.ClientOption) gopaypal.Client {
+	return gopaypal.NewClient(s.ClientID, s.Secret, s.URL, opts...)
+}
+
+// ApprovePayment approves the created payment with the given ID on behalf of the given payer, as
+// the buyer does on the approval URL. The payment can then be executed with the same payer ID
+func (s *Server) ApprovePayment(paymentID, payerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[paymentID]
+
+	if !ok {
+		return fmt.Errorf("paypaltest: payment %v not found", paymentID)
+	}
+
+	if p.State != "created" {
+		return fmt.Errorf("paypaltest: payment %v cannot be approved in state %v", paymentID, p.State)
+	}
+
+	p.approvedBy = payerID
+
+	return nil
+}
+
+// Payment returns a copy of the payment with the given ID as currently held by the server
+func (s *Server) Payment(paymentID string) (*gopaypal.PaymentResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[paymentID]
+
+	if !ok {
+		return nil, false
+	}
+
+	res := p.PaymentResponse
+
+	return &res, true
+}
+
+// IdentityCode returns a single use authorization code that the identity token service exchanges
+// for an access token of the given user, as PayPal does after a successful log in
+func (s *Server) IdentityCode(user gopaypal.IdentityUserInfoResponse) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := s.id("C21AA")
+	s.codes[code] = user
+
+	return code
+}
+
+// ExpireTokens revokes every issued OAuth2 application token. Following requests using them are
+// rejected with 401 until a new token is requested
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+// Fail makes the next given number of requests with the given method and path fail with the given
+// status and error. An empty method matches every method. A zero status drops the connection
+// without response
+func (s *Server) Fail(method, path string, times int, status int, e gopaypal.PayPalError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure{
+		method: method,
+		path:   path,
+		times:  times,
+		status: status,
+		err:    e,
+	})
+}
+
+// serve handles every request made to the server
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	// Fail the request if an error was injected
+	if f, ok := s.failure(r); ok {
+		if f.status == 0 {
+			dropConnection(w)
+			return
+		}
+
+		writeError(w, f.status, f.err)
+		return
+	}
+
+	// Replay the response of a repeated mutating request
+	key := ""
+
+	if id := r.Header.Get(gopaypal.RequestIDHeader); id != "" && r.Method != http.MethodGet {
+		key = r.Method + " " + r.URL.Path + " " + id
+
+		if rec, ok := s.response(key); ok {
+			replay(w, rec)
+			return
+		}
+	}
+
+	rec := httptest.NewRecorder()
+
+	s.route(rec, r)
+
+	if key != "" && rec.Code < 300 {
+		s.mu.Lock()
+		s.responses[key] = rec
+		s.mu.Unlock()
+	}
+
+	replay(w, rec)
+}
+
+// route dispatches the request to its endpoint handler
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == gopaypal.OAuthURL:
+		s.serveAccessToken(w, r)
+	case r.URL.Path == gopaypal.IdentityTokenURL:
+		s.serveIdentityToken(w, r)
+	case strings.HasPrefix(r.URL.Path, strings.SplitN(gopaypal.IdentityUserInfoURL, "?", 2)[0]):
+		s.serveUserInfo(w, r)
+	case r.URL.Path == gopaypal.PaymentCreateURL || strings.HasPrefix(r.URL.Path, gopaypal.PaymentCreateURL+"/"):
+		if !s.authorized(w, r) {
+			return
+		}
+
+		s.servePayments(w, r)
+	default:
+		notFound(w)
+	}
+}
+
+// serveAccessToken issues OAuth2 application tokens
+func (s *Server) serveAccessToken(w http.ResponseWriter, r *http.Request) {
+	form, ok := s.tokenRequest(w, r)
+
+	if !ok {
+		return
+	}
+
+	if form.Get("grant_type") != "client_credentials" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "Grant Type is NULL or not supported")
+		return
+	}
+
+	s.mu.Lock()
+
+	tkn := s.id("A21AA")
+	s.tokens[tkn] = time.Now().Add(s.TokenLifetime)
+
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, gopaypal.OAuthResponse{
+		Scope:       "https://uri.paypal.com/services/payments/payment openid",
+		Nonce:       time.Now().UTC().Format(time.RFC3339) + gopaypal.CreateNonce(),
+		AccessToken: tkn,
+		TokenType:   "Bearer",
+		AppID:       appID,
+		ExpiresIn:   int(s.TokenLifetime / time.Second),
+	})
+}
+
+// serveIdentityToken exchanges identity authorization codes and refresh tokens for access tokens
+func (s *Server) serveIdentityToken(w http.ResponseWriter, r *http.Request) {
+	form, ok := s.tokenRequest(w, r)
+
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := gopaypal.IdentityAccessTokenResponse{
+		TokenType: "Bearer",
+		Expires:   fmt.Sprint(identityTokenSeconds),
+	}
+
+	var user gopaypal.IdentityUserInfoResponse
+
+	switch form.Get("grant_type") {
+	case "authorization_code":
+		u, ok := s.codes[form.Get("code")]
+
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "Invalid authorization code")
+			return
+		}
+
+		// Codes are single use
+		delete(s.codes, form.Get("code"))
+
+		user = u
+		res.RefreshToken = s.id("R23AA")
+		s.refresh[res.RefreshToken] = user
+	case "refresh_token":
+		u, ok := s.refresh[form.Get("refresh_token")]
+
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "Invalid refresh token")
+			return
+		}
+
+		user = u
+	default:
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "Grant Type is NULL or not supported")
+		return
+	}
+
+	res.AccessToken = s.id("A23AA")
+	s.identity[res.AccessToken] = user
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// serveUserInfo returns the profile of the user owning the identity access token
+func (s *Server) serveUserInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	user, ok := s.identity[bearer(r)]
+	s.mu.Unlock()
+
+	if !ok {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_token", "The token passed in was not found in the system")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+// servePayments handles the v1 payment endpoints
+func (s *Server) servePayments(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, gopaypal.PaymentCreateURL), "/"), "/")
+
+	switch {
+	case parts[0] == "" && r.Method == http.MethodPost:
+		s.createPayment(w, r)
+	case len(parts) == 1 && parts[0] != "" && r.Method == http.MethodGet:
+		s.getPayment(w, parts[0])
+	case len(parts) == 2 && parts[1] == "execute" && r.Method == http.MethodPost:
+		s.executePayment(w, r, parts[0])
+	default:
+		notFound(w)
+	}
+}
+
+// createPayment creates a payment waiting for the buyer approval
+func (s *Server) createPayment(w http.ResponseWriter, r *http.Request) {
+	req := gopaypal.Payment{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, gopaypal.PayPalError{
+			Name:    "MALFORMED_REQUEST",
+			Message: "Incoming JSON request does not map to API request",
+		})
+		return
+	}
+
+	if details := validatePayment(req); len(details) > 0 {
+		writeError(w, http.StatusBadRequest, gopaypal.PayPalError{
+			Name:    "VALIDATION_ERROR",
+			Message: "Invalid request - see details",
+			Details: details,
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	p := &payment{
+		PaymentResponse: gopaypal.PaymentResponse{
+			ID:           s.id("PAY-"),
+			CreateTime:   now,
+			UpdateTime:   now,
+			State:        "created",
+			Intent:       req.Intent,
+			Payer:        req.Payer,
+			Transactions: req.Transactions,
+		},
+	}
+
+	p.Links = gopaypal.Links{
+		{Href: s.URL + fmt.Sprintf(gopaypal.PaymentInfoURL, p.ID), Rel: "self", Method: http.MethodGet},
+		{Href: s.URL + "/cgi-bin/webscr?cmd=_express-checkout&token=" + s.id("EC-"), Rel: "approval_url", Method: "REDIRECT"},
+		{Href: s.URL + fmt.Sprintf(gopaypal.PaymentExecuteURL, p.ID), Rel: "execute", Method: http.MethodPost},
+	}
+
+	s.payments[p.ID] = p
+
+	writeJSON(w, http.StatusCreated, p.PaymentResponse)
+}
+
+// getPayment returns the payment with the given ID
+func (s *Server) getPayment(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[id]
+
+	if !ok {
+		writeError(w, http.StatusNotFound, gopaypal.PayPalError{
+			Name:    "INVALID_RESOURCE_ID",
+			Message: "Requested resource ID was not found.",
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, p.PaymentResponse)
+}
+
+// executePayment executes the approved payment with the given ID creating its related resources
+func (s *Server) executePayment(w http.ResponseWriter, r *http.Request, id string) {
+	req := struct {
+		PayerID string `json:"payer_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, gopaypal.PayPalError{
+			Name:    "MALFORMED_REQUEST",
+			Message: "Incoming JSON request does not map to API request",
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[id]
+
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, gopaypal.PayPalError{
+			Name:    "INVALID_RESOURCE_ID",
+			Message: "Requested resource ID was not found.",
+		})
+		return
+	case p.State != "created":
+		writeError(w, http.StatusBadRequest, gopaypal.PayPalError{
+			Name:    "PAYMENT_STATE_INVALID",
+			Message: "This request is invalid due to the current state of the payment",
+		})
+		return
+	case p.approvedBy == "":
+		writeError(w, http.StatusBadRequest, gopaypal.PayPalError{
+			Name:    "PAYMENT_NOT_APPROVED_FOR_EXECUTION",
+			Message: "Payer has not approved payment",
+		})
+		return
+	case p.approvedBy != req.PayerID:
+		writeError(w, http.StatusBadRequest, gopaypal.PayPalError{
+			Name:    "INVALID_PAYER_ID",
+			Message: "Payer ID is invalid",
+		})
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	p.State = "approved"
+	p.UpdateTime = now
+	p.Payer.Status = "VERIFIED"
+	p.Payer.Info.ID = req.PayerID
+
+	for i, t := range p.Transactions {
+		p.Transactions[i].RelatedResources = []*gopaypal.RelatedResources{s.relatedResource(p, t, now)}
+	}
+
+	p.Links = gopaypal.Links{
+		{Href: s.URL + fmt.Sprintf(gopaypal.PaymentInfoURL, p.ID), Rel: "self", Method: http.MethodGet},
+	}
+
+	writeJSON(w, http.StatusOK, p.PaymentResponse)
+}
+
+// relatedResource creates the resource of an executed payment transaction according to the payment intent
+func (s *Server) relatedResource(p *payment, t gopaypal.Transaction, now time.Time) *gopaypal.RelatedResources {
+	created := now.Format(time.RFC3339)
+
+	switch p.Intent {
+	case "authorize":
+		id := s.id("AUTH-")
+
+		return &gopaypal.RelatedResources{
+			Authorization: &gopaypal.Authorization{
+				ID:            id,
+				Amount:        t.Amount,
+				PaymentMode:   "INSTANT_TRANSFER",
+				State:         "authorized",
+				ParentPayment: p.ID,
+				ValidUntil:    now.Add(29 * 24 * time.Hour).Format(time.RFC3339),
+				CreateTime:    created,
+				UpdateTime:    created,
+				Links: gopaypal.Links{
+					{Href: s.URL + fmt.Sprintf(gopaypal.AuthorizationURL, id), Rel: "self", Method: http.MethodGet},
+				},
+			},
+		}
+	case "order":
+		id := s.id("O-")
+
+		return &gopaypal.RelatedResources{
+			Order: &gopaypal.PaymentOrder{
+				ID:            id,
+				Amount:        t.Amount,
+				PaymentMode:   "INSTANT_TRANSFER",
+				State:         "pending",
+				ReasonCode:    "order",
+				ParentPayment: p.ID,
+				CreateTime:    created,
+				UpdateTime:    created,
+			},
+		}
+	}
+
+	id := s.id("SALE-")
+
+	return &gopaypal.RelatedResources{
+		Sale: &gopaypal.Sale{
+			ID:                    id,
+			Amount:                t.Amount,
+			PaymentMode:           "INSTANT_TRANSFER",
+			State:                 "completed",
+			ProtectionEligibility: "ELIGIBLE",
+			ParentPayment:         p.ID,
+			CreateTime:            created,
+			UpdateTime:            created,
+			Links: gopaypal.Links{
+				{Href: s.URL + fmt.Sprintf(gopaypal.SaleURL, id), Rel: "self", Method: http.MethodGet},
+				{Href: s.URL + fmt.Sprintf(gopaypal.SaleRefundURL, id), Rel: "refund", Method: http.MethodPost},
+			},
+		},
+	}
+}
+
+// tokenRequest checks the client credentials of a token request and returns its form values
+func (s *Server) tokenRequest(w http.ResponseWriter, r *http.Request) (url.Values, bool) {
+	if r.Method != http.MethodPost {
+		notFound(w)
+		return nil, false
+	}
+
+	if id, secret, ok := r.BasicAuth(); !ok || id != s.ClientID || secret != s.Secret {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "Client Authentication failed")
+		return nil, false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return nil, false
+	}
+
+	return r.PostForm, true
+}
+
+// authorized checks the request carries a valid application token
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	expires, ok := s.tokens[bearer(r)]
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(expires) {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_token", "Access Token not found in cache")
+		return false
+	}
+
+	return true
+}
+
+// failure returns the injected failure matching the request, if any
+func (s *Server) failure(r *http.Request) (failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.failures {
+		if (f.method != "" && f.method != r.Method) || f.path != r.URL.Path {
+			continue
+		}
+
+		f.times--
+
+		if f.times <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+
+		return *f, true
+	}
+
+	return failure{}, false
+}
+
+// response returns the recorded response of a mutating request
+func (s *Server) response(key string) (*httptest.ResponseRecorder, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.responses[key]
+
+	return rec, ok
+}
+
+// id returns a new resource identifier with the given prefix. The server lock must be held
+func (s *Server) id(prefix string) string {
+	s.seq++
+
+	return fmt.Sprintf("%v%06d%v", prefix, s.seq, strings.ToUpper(gopaypal.CreateNonce()))
+}
+
+// validatePayment returns the validation issues of a payment to create
+func validatePayment(p gopaypal.Payment) []gopaypal.ErrorDetail {
+	details := []gopaypal.ErrorDetail{}
+
+	switch p.Intent {
+	case "sale", "authorize", "order":
+	default:
+		details = append(details, gopaypal.ErrorDetail{
+			Field: "intent",
+			Issue: "Must be sale, authorize or order",
+		})
+	}
+
+	if p.Payer.PaymentMethod == "" {
+		details = append(details, gopaypal.ErrorDetail{
+			Field: "payer.payment_method",
+			Issue: "Required field missing",
+		})
+	}
+
+	if len(p.Transactions) == 0 {
+		details = append(details, gopaypal.ErrorDetail{
+			Field: "transactions",
+			Issue: "Required field missing",
+		})
+	}
+
+	for i, t := range p.Transactions {
+		if t.Amount.Total == "" || t.Amount.Currency == "" {
+			details = append(details, gopaypal.ErrorDetail{
+				Field: fmt.Sprintf("transactions[%v].amount", i),
+				Issue: "Amount total and currency are required",
+			})
+		}
+	}
+
+	return details
+}
+
+// bearer returns the bearer token of the request
+func bearer(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// replay writes the recorded response
+func replay(w http.ResponseWriter, rec *httptest.ResponseRecorder) {
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+// writeJSON writes the given value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a REST API error response
+func writeError(w http.ResponseWriter, status int, e gopaypal.PayPalError) {
+	if e.DebugID == "" {
+		e.DebugID = strings.ToLower(gopaypal.CreateNonce())
+	}
+
+	w.Header().Set(DebugIDHeader, e.DebugID)
+
+	writeJSON(w, status, e)
+}
+
+// writeOAuthError writes an OAuth2 error response
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// notFound writes the response of an unknown endpoint
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, gopaypal.PayPalError{
+		Name:    "RESOURCE_NOT_FOUND",
+		Message: "The requested resource was not found",
+	})
+}
+
+// dropConnection closes the connection without writing a response. The request fails with an
+// internal error when the connection cannot be taken over
+func dropConnection(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+
+	if !ok {
+		http.Error(w, "paypaltest: response writer cannot drop the connection", http.StatusInternalServerError)
+		return
+	}
+
+	conn, _, err := hj.Hijack()
+
+	if err != nil {
+		http.Error(w, "paypaltest: cannot drop the connection: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	conn.Close()
+}
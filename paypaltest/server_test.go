@@ -0,0 +1,346 @@
+package paypaltest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joseluis2g/gopaypal"
+)
+
+// testPayment returns a valid payment to create
+func testPayment(intent string) gopaypal.Payment {
+	return gopaypal.Payment{
+		Intent: intent,
+		Payer: gopaypal.Payer{
+			PaymentMethod: "paypal",
+		},
+		Transactions: []gopaypal.Transaction{
+			{
+				Amount: gopaypal.Amount{
+					Total:    "10.00",
+					Currency: "EUR",
+				},
+				Description: "paypaltest payment",
+			},
+		},
+		RedirectURL: gopaypal.RedirectURL{
+			ReturnURL: "https://example.com/return",
+			CancelURL: "https://example.com/cancel",
+		},
+	}
+}
+
+func TestServer_PaymentFlow(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	// Create payment
+	res, err := client.CreatePayment(testPayment("sale"))
+
+	if err != nil {
+		t.Errorf("Cannot create payment: %v", err)
+		t.FailNow()
+	}
+
+	if res.ID == "" || res.State != "created" || res.ApprovalURL() == "" {
+		t.Errorf("Unexpected created payment %+v", res)
+		t.FailNow()
+	}
+
+	// Executing before approval fails
+	if _, err := client.ExecutePayment(res.ID, "PAYER-1"); !gopaypal.IsValidation(err) {
+		t.Errorf("Unexpected execution error of unapproved payment: %v", err)
+		t.FailNow()
+	}
+
+	// Approve and execute payment
+	if err := srv.ApprovePayment(res.ID, "PAYER-1"); err != nil {
+		t.Errorf("Cannot approve payment: %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.ExecutePayment(res.ID, "PAYER-2"); !gopaypal.IsValidation(err) {
+		t.Errorf("Unexpected execution error with another payer: %v", err)
+		t.FailNow()
+	}
+
+	executed, err := client.ExecutePayment(res.ID, "PAYER-1")
+
+	if err != nil {
+		t.Errorf("Cannot execute payment: %v", err)
+		t.FailNow()
+	}
+
+	if executed.State != "approved" || executed.Payer.Info.ID != "PAYER-1" {
+		t.Errorf("Unexpected executed payment %+v", executed)
+		t.FailNow()
+	}
+
+	sale := executed.Transactions[0].RelatedResources[0].Sale
+
+	if sale == nil {
+		t.Errorf("Missing sale on executed payment")
+		t.FailNow()
+	}
+
+	if sale.State != "completed" || sale.ParentPayment != res.ID || sale.Amount.Total != "10.00" {
+		t.Errorf("Unexpected sale %+v", sale)
+		t.FailNow()
+	}
+
+	// Executed payments cannot be executed again
+	if _, err := client.ExecutePayment(res.ID, "PAYER-1"); !gopaypal.IsValidation(err) {
+		t.Errorf("Unexpected second execution error: %v", err)
+		t.FailNow()
+	}
+
+	// Look up payment
+	info, err := client.PaymentInformation(res.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get payment information: %v", err)
+		t.FailNow()
+	}
+
+	if info.State != "approved" {
+		t.Errorf("Unexpected payment state. Got %v expected %v", info.State, "approved")
+		t.FailNow()
+	}
+
+	if _, err := client.PaymentInformation("PAY-MISSING"); !gopaypal.IsNotFound(err) {
+		t.Errorf("Unexpected missing payment error: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestServer_PaymentValidation(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	p := testPayment("sale")
+	p.Payer.PaymentMethod = ""
+
+	_, err := client.CreatePayment(p)
+
+	var perr *gopaypal.PayPalError
+
+	if !gopaypal.IsValidation(err) || !errors.As(err, &perr) {
+		t.Errorf("Unexpected validation error: %v", err)
+		t.FailNow()
+	}
+
+	if len(perr.Details) != 1 || perr.Details[0].Field != "payer.payment_method" || perr.DebugID == "" {
+		t.Errorf("Unexpected validation error details %+v", perr)
+		t.FailNow()
+	}
+}
+
+func TestServer_PaymentIntents(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	res, err := client.CreatePayment(testPayment("authorize"))
+
+	if err != nil {
+		t.Errorf("Cannot create payment: %v", err)
+		t.FailNow()
+	}
+
+	srv.ApprovePayment(res.ID, "PAYER-1")
+
+	executed, err := client.ExecutePayment(res.ID, "PAYER-1")
+
+	if err != nil {
+		t.Errorf("Cannot execute payment: %v", err)
+		t.FailNow()
+	}
+
+	if auth := executed.Transactions[0].RelatedResources[0].Authorization; auth == nil || auth.State != "authorized" || auth.ID == "" {
+		t.Errorf("Unexpected authorization %+v", auth)
+		t.FailNow()
+	}
+}
+
+func TestServer_AccessToken(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	// Get token with valid credentials
+	client := srv.Client()
+
+	tkn, err := client.GetAccessToken()
+
+	if err != nil {
+		t.Errorf("Cannot get access token: %v", err)
+		t.FailNow()
+	}
+
+	if tkn.AccessToken == "" || tkn.ExpiresIn != int(srv.TokenLifetime/time.Second) {
+		t.Errorf("Unexpected access token %+v", tkn)
+		t.FailNow()
+	}
+
+	// Get token with invalid credentials
+	client = gopaypal.NewClient(srv.ClientID, "wrong", srv.URL)
+
+	if _, err := client.GetAccessToken(); !gopaypal.IsAuth(err) {
+		t.Errorf("Unexpected invalid credentials error: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestServer_ExpireTokens(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	res, err := client.CreatePayment(testPayment("sale"))
+
+	if err != nil {
+		t.Errorf("Cannot create payment: %v", err)
+		t.FailNow()
+	}
+
+	// The client renews the revoked token
+	srv.ExpireTokens()
+
+	if _, err := client.PaymentInformation(res.ID); err != nil {
+		t.Errorf("Cannot get payment information after token expiration: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestServer_Identity(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	code := srv.IdentityCode(gopaypal.IdentityUserInfoResponse{
+		UserID: "https://www.paypal.com/webapps/auth/identity/user/1",
+		Email:  "buyer@example.com",
+	})
+
+	// Exchange code for a token
+	res, err := client.GetTokenFromIdentityCode(code, "https://example.com/return")
+
+	if err != nil {
+		t.Errorf("Cannot get token from identity code: %v", err)
+		t.FailNow()
+	}
+
+	if res.AccessToken == "" || res.RefreshToken == "" || res.TokenType != "Bearer" {
+		t.Errorf("Unexpected identity token %+v", res)
+		t.FailNow()
+	}
+
+	// Codes are single use
+	if _, err := client.GetTokenFromIdentityCode(code, "https://example.com/return"); err == nil {
+		t.Error("Identity code accepted twice")
+		t.FailNow()
+	}
+
+	// Get user info
+	info, err := client.GetUserInfo(res.AccessToken)
+
+	if err != nil {
+		t.Errorf("Cannot get user info: %v", err)
+		t.FailNow()
+	}
+
+	if info.Email != "buyer@example.com" {
+		t.Errorf("Unexpected user email. Got %v expected %v", info.Email, "buyer@example.com")
+		t.FailNow()
+	}
+
+	// Refresh token
+	refreshed, err := client.GetTokenFromRefreshToken(res.RefreshToken)
+
+	if err != nil {
+		t.Errorf("Cannot get token from refresh token: %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.GetUserInfo(refreshed.AccessToken); err != nil {
+		t.Errorf("Cannot get user info with refreshed token: %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.GetUserInfo("invalid"); !gopaypal.IsAuth(err) {
+		t.Errorf("Unexpected invalid token error: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestServer_Fail(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client(gopaypal.WithRetryPolicy(gopaypal.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+	}))
+
+	// Fail payment creation twice, then succeed once retried
+	srv.Fail(http.MethodPost, gopaypal.PaymentCreateURL, 1, http.StatusServiceUnavailable, gopaypal.PayPalError{
+		Name:    "SERVICE_UNAVAILABLE",
+		Message: "Service Unavailable",
+	})
+	srv.Fail(http.MethodPost, gopaypal.PaymentCreateURL, 1, 0, gopaypal.PayPalError{})
+
+	ctx := gopaypal.ContextWithRequestID(context.Background(), "order-1")
+
+	res, err := client.CreatePaymentWithContext(ctx, testPayment("sale"))
+
+	if err != nil {
+		t.Errorf("Cannot create payment: %v", err)
+		t.FailNow()
+	}
+
+	// Repeating the request returns the same payment
+	again, err := client.CreatePaymentWithContext(ctx, testPayment("sale"))
+
+	if err != nil {
+		t.Errorf("Cannot create payment again: %v", err)
+		t.FailNow()
+	}
+
+	if again.ID != res.ID {
+		t.Errorf("Idempotent request created a new payment. Got %v expected %v", again.ID, res.ID)
+		t.FailNow()
+	}
+
+	// Fail without retries
+	srv.Fail("", gopaypal.PaymentCreateURL+"/"+res.ID, 1, http.StatusTooManyRequests, gopaypal.PayPalError{
+		Name:    "RATE_LIMIT_REACHED",
+		Message: "Too many requests",
+	})
+
+	if _, err := srv.Client().PaymentInformation(res.ID); !gopaypal.IsRateLimited(err) {
+		t.Errorf("Unexpected injected error: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestDropConnection(t *testing.T) {
+	// Writers that cannot be taken over fail the request
+	rec := httptest.NewRecorder()
+
+	dropConnection(rec)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Unexpected status. Got %v expected %v", rec.Code, http.StatusInternalServerError)
+		t.FailNow()
+	}
+}
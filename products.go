@@ -0,0 +1,231 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ProductType is the type of a catalog product
+type ProductType string
+
+const (
+	ProductTypePhysical ProductType = "PHYSICAL"
+	ProductTypeDigital  ProductType = "DIGITAL"
+	ProductTypeService  ProductType = "SERVICE"
+)
+
+// ProductCategory is the category of a catalog product. Only the most usual categories are
+// declared, any other PayPal category can be used converting its name
+type ProductCategory string
+
+const (
+	ProductCategoryAcademicSoftware                ProductCategory = "ACADEMIC_SOFTWARE"
+	ProductCategoryBooksAndMagazines               ProductCategory = "BOOKS_AND_MAGAZINES"
+	ProductCategoryDigitalGames                    ProductCategory = "DIGITAL_GAMES"
+	ProductCategoryDigitalMediaBooksMoviesMusic    ProductCategory = "DIGITAL_MEDIA_BOOKS_MOVIES_MUSIC"
+	ProductCategoryEducationalAndTextbooks         ProductCategory = "EDUCATIONAL_AND_TEXTBOOKS"
+	ProductCategoryEntertainment                   ProductCategory = "ENTERTAINMENT"
+	ProductCategoryMembershipClubsAndOrganizations ProductCategory = "MEMBERSHIP_CLUBS_AND_ORGANIZATIONS"
+	ProductCategoryOnlineGaming                    ProductCategory = "ONLINE_GAMING"
+	ProductCategorySoftware                        ProductCategory = "SOFTWARE"
+	ProductCategoryStreamingServices               ProductCategory = "STREAMING_SERVICES"
+)
+
+// Product is a catalog product, the goods or service billing plans are created for
+type Product struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Type        ProductType     `json:"type,omitempty"`
+	Category    ProductCategory `json:"category,omitempty"`
+	ImageURL    string          `json:"image_url,omitempty"`
+	HomeURL     string          `json:"home_url,omitempty"`
+	CreateTime  string          `json:"create_time,omitempty"`
+	UpdateTime  string          `json:"update_time,omitempty"`
+	Links       Links           `json:"links,omitempty"`
+	RequestID   string          `json:"-"`
+}
+
+// ProductListParams paginates the products returned by ListProducts. Zero values are not sent
+type ProductListParams struct {
+	PageSize      int
+	Page          int
+	TotalRequired bool
+}
+
+// ProductList is a page of products. The following page is found on the next link
+type ProductList struct {
+	Products   []Product `json:"products"`
+	TotalItems int       `json:"total_items,omitempty"`
+	TotalPages int       `json:"total_pages,omitempty"`
+	Links      Links     `json:"links,omitempty"`
+}
+
+// ProductIterator iterates over the catalog products, fetching pages as needed
+//
+//	it := client.IterateProducts(ctx, params)
+//
+//	for it.Next() {
+//		product := it.Product()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ProductIterator struct {
+	pager
+	page []Product
+}
+
+// Query returns the list parameters encoded as an URL query
+func (p ProductListParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+
+	if p.TotalRequired {
+		query.Set("total_required", "true")
+	}
+
+	return query
+}
+
+// CreateProduct creates a catalog product
+func (c Client) CreateProduct(product Product) (*Product, error) {
+	return c.CreateProductWithContext(context.Background(), product)
+}
+
+// CreateProductWithContext creates a catalog product using the given context
+func (c Client) CreateProductWithContext(ctx context.Context, product Product) (*Product, error) {
+	// Hold product response
+	d := Product{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, ProductsURL, &product, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// ListProducts gets a page of the catalog products
+func (c Client) ListProducts(params ProductListParams) (*ProductList, error) {
+	return c.ListProductsWithContext(context.Background(), params)
+}
+
+// ListProductsWithContext gets a page of the catalog products using the given context
+func (c Client) ListProductsWithContext(ctx context.Context, params ProductListParams) (*ProductList, error) {
+	return c.listProducts(ctx, productListEndpoint(params))
+}
+
+// IterateProducts returns an iterator over every catalog product starting at the given page
+func (c Client) IterateProducts(ctx context.Context, params ProductListParams) *ProductIterator {
+	it := &ProductIterator{}
+
+	it.pager = newPager(ctx, productListEndpoint(params), func(ctx context.Context, endpoint string) (int, string, error) {
+		list, err := c.listProducts(ctx, endpoint)
+
+		if err != nil {
+			return 0, "", err
+		}
+
+		it.page = list.Products
+
+		return len(list.Products), nextLink(list.Links), nil
+	})
+
+	return it
+}
+
+// GetProduct gets the details of the catalog product with the given ID
+func (c Client) GetProduct(productID string) (*Product, error) {
+	return c.GetProductWithContext(context.Background(), productID)
+}
+
+// GetProductWithContext gets the details of the catalog product with the given ID using the given context
+func (c Client) GetProductWithContext(ctx context.Context, productID string) (*Product, error) {
+	// Hold product response
+	d := Product{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(ProductURL, productID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// UpdateProduct updates the catalog product with the given ID applying the JSON Patch operations.
+// Only the description, category, image_url and home_url can be updated
+func (c Client) UpdateProduct(productID string, patch Patch) error {
+	return c.UpdateProductWithContext(context.Background(), productID, patch)
+}
+
+// UpdateProductWithContext updates the catalog product with the given ID applying the JSON Patch
+// operations using the given context
+func (c Client) UpdateProductWithContext(ctx context.Context, productID string, patch Patch) error {
+	// Validate patch operations
+	if err := patch.Validate(); err != nil {
+		return err
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPatch, fmt.Sprintf(ProductURL, productID), patch, nil)
+
+	return err
+}
+
+// Next advances the iterator to the next product. It returns false when there are no more
+// products or an error happened
+func (it *ProductIterator) Next() bool {
+	return it.next()
+}
+
+// Product returns the current product
+func (it *ProductIterator) Product() *Product {
+	i, ok := it.current()
+
+	if !ok {
+		return nil
+	}
+
+	return &it.page[i]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ProductIterator) Err() error {
+	return it.err
+}
+
+// listProducts gets the product page at the given endpoint
+func (c Client) listProducts(ctx context.Context, endpoint string) (*ProductList, error) {
+	// Hold product list response
+	d := ProductList{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, endpoint, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// productListEndpoint returns the product list endpoint with the given parameters
+func productListEndpoint(params ProductListParams) string {
+	query := params.Query()
+
+	if len(query) == 0 {
+		return ProductsURL
+	}
+
+	return ProductsURL + "?" + query.Encode()
+}
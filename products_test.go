@@ -0,0 +1,165 @@
+package gopaypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_CreateProduct(t *testing.T) {
+	var patch Patch
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/catalogs/products": func(w http.ResponseWriter, r *http.Request) {
+			product := Product{}
+
+			if err := json.NewDecoder(r.Body).Decode(&product); err != nil || product.Type != ProductTypeService {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "PROD-XXCD1234QWER65782", "name": "Video Streaming Service", "type": "SERVICE", "category": "SOFTWARE"}`))
+		},
+		"PATCH /v1/catalogs/products/PROD-XXCD1234QWER65782": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&patch)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /v1/catalogs/products/PROD-XXCD1234QWER65782": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "PROD-XXCD1234QWER65782", "name": "Video Streaming Service", "description": "Premium video streaming service", "type": "SERVICE", "category": "SOFTWARE"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	product, err := client.CreateProduct(Product{
+		Name:     "Video Streaming Service",
+		Type:     ProductTypeService,
+		Category: ProductCategorySoftware,
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create product: %v", err)
+		t.FailNow()
+	}
+
+	if product.ID != "PROD-XXCD1234QWER65782" || product.Category != ProductCategorySoftware || product.RequestID == "" {
+		t.Errorf("Unexpected created product %+v", product)
+		t.FailNow()
+	}
+
+	// Empty patches are rejected before sending them
+	if err := client.UpdateProduct(product.ID, Patch{}); err == nil {
+		t.Errorf("Expected an error updating a product with an empty patch")
+		t.FailNow()
+	}
+
+	if err := client.UpdateProduct(product.ID, Patch{}.Replace("/description", "Premium video streaming service")); err != nil {
+		t.Errorf("Cannot update product: %v", err)
+		t.FailNow()
+	}
+
+	if len(patch) != 1 || patch[0].Path != "/description" {
+		t.Errorf("Unexpected product patch %+v", patch)
+		t.FailNow()
+	}
+
+	product, err = client.GetProduct(product.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get product: %v", err)
+		t.FailNow()
+	}
+
+	if product.Description != "Premium video streaming service" {
+		t.Errorf("Unexpected product description. Got %v expected %v", product.Description, "Premium video streaming service")
+		t.FailNow()
+	}
+}
+
+func TestClient_IterateProducts(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/catalogs/products": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page_size") != "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			switch r.URL.Query().Get("page") {
+			case "":
+				w.Write([]byte(`{"products": [{"id": "PROD-1"}, {"id": "PROD-2"}], "links": [
+					{"href": "https://api.sandbox.paypal.com/v1/catalogs/products?page_size=2&page=2", "rel": "next", "method": "GET"}
+				]}`))
+			case "2":
+				w.Write([]byte(`{"products": [{"id": "PROD-3"}]}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	it := client.IterateProducts(context.Background(), ProductListParams{PageSize: 2})
+	ids := []string{}
+
+	for it.Next() {
+		ids = append(ids, it.Product().ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Errorf("Cannot iterate products: %v", err)
+		t.FailNow()
+	}
+
+	if len(ids) != 3 || ids[2] != "PROD-3" {
+		t.Errorf("Unexpected iterated products %v", ids)
+		t.FailNow()
+	}
+}
+
+func TestClient_ListProducts(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/catalogs/products": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+
+			if q.Get("page_size") != "10" || q.Get("page") != "3" || q.Get("total_required") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"products": [{"id": "PROD-21", "name": "Video Streaming Service"}], "total_items": 21, "total_pages": 3, "links": [
+				{"href": "https://api.sandbox.paypal.com/v1/catalogs/products?page_size=10&page=2", "rel": "prev", "method": "GET"}
+			]}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	list, err := client.ListProducts(ProductListParams{PageSize: 10, Page: 3, TotalRequired: true})
+
+	if err != nil {
+		t.Errorf("Cannot list products: %v", err)
+		t.FailNow()
+	}
+
+	if len(list.Products) != 1 || list.Products[0].ID != "PROD-21" || list.TotalItems != 21 || list.TotalPages != 3 {
+		t.Errorf("Unexpected product list %+v", list)
+		t.FailNow()
+	}
+
+	if _, ok := list.Links.ByRel("prev"); !ok {
+		t.Errorf("Missing previous page link on %+v", list.Links)
+		t.FailNow()
+	}
+}
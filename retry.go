@@ -0,0 +1,158 @@
+package gopaypal
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the client retries requests failing with transient errors. Only
+// idempotent requests or requests carrying a PayPal-Request-Id header are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It doubles on every retry
+	MinBackoff time.Duration
+
+	// MaxBackoff is the upper limit of the delay between retries, including delays requested by
+	// the Retry-After response header
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a retry policy suitable for most applications
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// WithRetryPolicy sets the policy used to retry requests failing with transient errors.
+// By default requests are not retried
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// attempts returns the total number of attempts allowed by the policy
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// retryable reports whether the failed request may be sent again. A nil response means the
+// request failed before getting one (e.g. a connection reset)
+func (p RetryPolicy) retryable(req *http.Request, res *http.Response) bool {
+	// Check if sending the request twice is safe
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if req.Header.Get(RequestIDHeader) == "" {
+			return false
+		}
+	}
+
+	if res == nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// delay returns the time to wait before the given attempt is retried. The Retry-After
+// response header takes precedence over the exponential backoff, both limited by MaxBackoff
+func (p RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if d, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			// Do not let the server stall the request for longer than allowed
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+
+			return d
+		}
+	}
+
+	// Exponential backoff
+	d := p.MinBackoff << uint(attempt-1)
+
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	// Add jitter between half and the whole delay
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header value given in seconds or as an HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for the given duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// canRewind reports whether the request body can be sent again
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind resets the request body so the request can be sent again
+func rewind(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package gopaypal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_ExecuteRetry(t *testing.T) {
+	var calls int32
+
+	// Create a fake PayPal server failing twice
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`{"id": "PAY-1"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}))
+
+	// Create basic request
+	req, err := client.BasicRequest("/v1/payments/payment/PAY-1", nil, http.MethodGet)
+
+	if err != nil {
+		t.Errorf("Cannot create request: %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.Execute(req); err != nil {
+		t.Errorf("Cannot execute request: %v", err)
+		t.FailNow()
+	}
+
+	if calls != 3 {
+		t.Errorf("Unexpected number of attempts. Got %v expected %v", calls, 3)
+		t.FailNow()
+	}
+
+	// Non idempotent requests without a request ID are not retried
+	atomic.StoreInt32(&calls, 0)
+
+	req, err = client.BasicRequest(PaymentCreateURL, []byte("{}"), http.MethodPost)
+
+	if err != nil {
+		t.Errorf("Cannot create request: %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.Execute(req); err == nil {
+		t.Error("Expected request to fail")
+		t.FailNow()
+	}
+
+	if calls != 1 {
+		t.Errorf("Unexpected number of attempts. Got %v expected %v", calls, 1)
+		t.FailNow()
+	}
+}
+
+func TestClient_ExecuteRefreshToken(t *testing.T) {
+	var tokens int32
+
+	// Create a fake PayPal server rejecting the first issued token
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OAuthURL {
+			if atomic.AddInt32(&tokens, 1) == 1 {
+				w.Write([]byte(`{"access_token": "expired", "expires_in": 32400}`))
+			} else {
+				w.Write([]byte(`{"access_token": "fresh", "expires_in": 32400}`))
+			}
+
+			return
+		}
+
+		if !strings.HasSuffix(r.Header.Get("Authorization"), "fresh") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_token", "error_description": "Token signature verification failed"}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "PAY-1", "state": "created"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get payment information
+	res, err := client.PaymentInformation("PAY-1")
+
+	if err != nil {
+		t.Errorf("Cannot get payment information: %v", err)
+		t.FailNow()
+	}
+
+	if res.ID != "PAY-1" {
+		t.Errorf("Unexpected payment ID. Got %v expected %v", res.ID, "PAY-1")
+		t.FailNow()
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("2"); !ok || d != 2*time.Second {
+		t.Errorf("Unexpected Retry-After delay %v", d)
+		t.FailNow()
+	}
+
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("Invalid Retry-After value parsed")
+		t.FailNow()
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	res := &http.Response{Header: http.Header{}}
+
+	res.Header.Set("Retry-After", "2")
+
+	if d := policy.delay(1, res); d != 2*time.Second {
+		t.Errorf("Unexpected Retry-After delay. Got %v expected %v", d, 2*time.Second)
+		t.FailNow()
+	}
+
+	// Retry-After delays are limited by the maximum backoff
+	res.Header.Set("Retry-After", "3600")
+
+	if d := policy.delay(1, res); d != policy.MaxBackoff {
+		t.Errorf("Unexpected Retry-After delay. Got %v expected %v", d, policy.MaxBackoff)
+		t.FailNow()
+	}
+
+	if d := policy.delay(10, nil); d > policy.MaxBackoff {
+		t.Errorf("Backoff delay %v over the maximum %v", d, policy.MaxBackoff)
+		t.FailNow()
+	}
+}
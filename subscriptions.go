@@ -0,0 +1,525 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IntervalDay   = "DAY"
+	IntervalWeek  = "WEEK"
+	IntervalMonth = "MONTH"
+	IntervalYear  = "YEAR"
+
+	TenureRegular = "REGULAR"
+	TenureTrial   = "TRIAL"
+
+	PlanStatusCreated  = "CREATED"
+	PlanStatusActive   = "ACTIVE"
+	PlanStatusInactive = "INACTIVE"
+
+	SubscriptionStatusApprovalPending = "APPROVAL_PENDING"
+	SubscriptionStatusApproved        = "APPROVED"
+	SubscriptionStatusActive          = "ACTIVE"
+	SubscriptionStatusSuspended       = "SUSPENDED"
+	SubscriptionStatusCancelled       = "CANCELLED"
+	SubscriptionStatusExpired         = "EXPIRED"
+)
+
+// Plan is a billing plan of the Subscriptions API, made of trial and regular billing cycles
+type Plan struct {
+	ID                 string              `json:"id,omitempty"`
+	ProductID          string              `json:"product_id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	Status             string              `json:"status,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	BillingCycles      []BillingCycle      `json:"billing_cycles,omitempty"`
+	PaymentPreferences *PaymentPreferences `json:"payment_preferences,omitempty"`
+	Taxes              *Taxes              `json:"taxes,omitempty"`
+	QuantitySupported  bool                `json:"quantity_supported,omitempty"`
+	CreateTime         string              `json:"create_time,omitempty"`
+	UpdateTime         string              `json:"update_time,omitempty"`
+	Links              Links               `json:"links,omitempty"`
+	RequestID          string              `json:"-"`
+}
+
+// BillingCycle is a trial or regular billing cycle of a plan. A zero TotalCycles bills the
+// cycle until the subscription is cancelled
+type BillingCycle struct {
+	Frequency     Frequency      `json:"frequency"`
+	TenureType    string         `json:"tenure_type"`
+	Sequence      int            `json:"sequence"`
+	TotalCycles   int            `json:"total_cycles"`
+	PricingScheme *PricingScheme `json:"pricing_scheme,omitempty"`
+}
+
+// Frequency is the interval between the billings of a cycle, e.g. every 3 months
+type Frequency struct {
+	IntervalUnit  string `json:"interval_unit"`
+	IntervalCount int    `json:"interval_count,omitempty"`
+}
+
+// PricingScheme is the price of a billing cycle. A nil fixed price makes the cycle free, as
+// usual for trials
+type PricingScheme struct {
+	Version      int           `json:"version,omitempty"`
+	FixedPrice   *Money        `json:"fixed_price,omitempty"`
+	PricingModel string        `json:"pricing_model,omitempty"`
+	Tiers        []PricingTier `json:"tiers,omitempty"`
+	CreateTime   string        `json:"create_time,omitempty"`
+	UpdateTime   string        `json:"update_time,omitempty"`
+}
+
+// PricingTier is the price of a quantity range of a volume or tiered pricing scheme
+type PricingTier struct {
+	StartingQuantity string `json:"starting_quantity"`
+	EndingQuantity   string `json:"ending_quantity,omitempty"`
+	Amount           Money  `json:"amount"`
+}
+
+// PricingSchemeUpdate replaces the pricing scheme of the plan billing cycle with the given sequence
+type PricingSchemeUpdate struct {
+	BillingCycleSequence int           `json:"billing_cycle_sequence"`
+	PricingScheme        PricingScheme `json:"pricing_scheme"`
+}
+
+// PaymentPreferences configures the setup fee and the failed payments handling of a plan. A nil
+// AutoBillOutstanding keeps the PayPal default of billing the outstanding amount
+type PaymentPreferences struct {
+	AutoBillOutstanding     *bool  `json:"auto_bill_outstanding,omitempty"`
+	SetupFee                *Money `json:"setup_fee,omitempty"`
+	SetupFeeFailureAction   string `json:"setup_fee_failure_action,omitempty"`
+	PaymentFailureThreshold int    `json:"payment_failure_threshold,omitempty"`
+}
+
+// Taxes holds the tax percentage of a plan
+type Taxes struct {
+	Percentage string `json:"percentage"`
+	Inclusive  bool   `json:"inclusive"`
+}
+
+// PlanListParams filters and paginates the plans returned by ListPlans. Zero values are not sent
+type PlanListParams struct {
+	ProductID     string
+	PlanIDs       []string
+	PageSize      int
+	Page          int
+	TotalRequired bool
+}
+
+// PlanList is a page of plans
+type PlanList struct {
+	Plans      []Plan `json:"plans"`
+	TotalItems int    `json:"total_items,omitempty"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	Links      Links  `json:"links,omitempty"`
+}
+
+// Subscription is a subscription of a subscriber to a plan
+type Subscription struct {
+	ID                 string                          `json:"id,omitempty"`
+	PlanID             string                          `json:"plan_id"`
+	Status             string                          `json:"status,omitempty"`
+	StatusChangeNote   string                          `json:"status_change_note,omitempty"`
+	StatusUpdateTime   string                          `json:"status_update_time,omitempty"`
+	StartTime          string                          `json:"start_time,omitempty"`
+	Quantity           string                          `json:"quantity,omitempty"`
+	ShippingAmount     *Money                          `json:"shipping_amount,omitempty"`
+	Subscriber         *Subscriber                     `json:"subscriber,omitempty"`
+	BillingInfo        *SubscriptionBillingInfo        `json:"billing_info,omitempty"`
+	ApplicationContext *SubscriptionApplicationContext `json:"application_context,omitempty"`
+	CustomID           string                          `json:"custom_id,omitempty"`
+	CreateTime         string                          `json:"create_time,omitempty"`
+	UpdateTime         string                          `json:"update_time,omitempty"`
+	Links              Links                           `json:"links,omitempty"`
+	RequestID          string                          `json:"-"`
+}
+
+// Subscriber is the payer of a subscription
+type Subscriber struct {
+	Name            *Name           `json:"name,omitempty"`
+	EmailAddress    string          `json:"email_address,omitempty"`
+	PayerID         string          `json:"payer_id,omitempty"`
+	ShippingAddress *ShippingDetail `json:"shipping_address,omitempty"`
+}
+
+// SubscriptionBillingInfo holds the billing state of a subscription
+type SubscriptionBillingInfo struct {
+	OutstandingBalance  *Money           `json:"outstanding_balance,omitempty"`
+	CycleExecutions     []CycleExecution `json:"cycle_executions,omitempty"`
+	LastPayment         *LastPayment     `json:"last_payment,omitempty"`
+	NextBillingTime     string           `json:"next_billing_time,omitempty"`
+	FailedPaymentsCount int              `json:"failed_payments_count,omitempty"`
+}
+
+// CycleExecution holds the billed cycles of a plan billing cycle
+type CycleExecution struct {
+	TenureType                  string `json:"tenure_type"`
+	Sequence                    int    `json:"sequence"`
+	CyclesCompleted             int    `json:"cycles_completed"`
+	CyclesRemaining             int    `json:"cycles_remaining,omitempty"`
+	CurrentPricingSchemeVersion int    `json:"current_pricing_scheme_version,omitempty"`
+	TotalCycles                 int    `json:"total_cycles,omitempty"`
+}
+
+// LastPayment is the last payment of a subscription
+type LastPayment struct {
+	Amount Money  `json:"amount"`
+	Time   string `json:"time"`
+}
+
+// SubscriptionApplicationContext customizes the payer experience approving a subscription
+type SubscriptionApplicationContext struct {
+	BrandName          string `json:"brand_name,omitempty"`
+	Locale             string `json:"locale,omitempty"`
+	ShippingPreference string `json:"shipping_preference,omitempty"`
+	UserAction         string `json:"user_action,omitempty"`
+	ReturnURL          string `json:"return_url,omitempty"`
+	CancelURL          string `json:"cancel_url,omitempty"`
+}
+
+// SubscriptionRevision holds the plan, quantity or shipping changes of a subscription. The
+// response holds the approve link the subscriber must follow when the change needs approval
+type SubscriptionRevision struct {
+	PlanID             string                          `json:"plan_id,omitempty"`
+	Quantity           string                          `json:"quantity,omitempty"`
+	EffectiveTime      string                          `json:"effective_time,omitempty"`
+	ShippingAmount     *Money                          `json:"shipping_amount,omitempty"`
+	ShippingAddress    *ShippingDetail                 `json:"shipping_address,omitempty"`
+	ApplicationContext *SubscriptionApplicationContext `json:"application_context,omitempty"`
+	PlanOverridden     bool                            `json:"plan_overridden,omitempty"`
+	Links              Links                           `json:"links,omitempty"`
+	RequestID          string                          `json:"-"`
+}
+
+// SubscriptionTransaction is a payment of a subscription
+type SubscriptionTransaction struct {
+	ID                  string                       `json:"id"`
+	Status              string                       `json:"status"`
+	AmountWithBreakdown *SubscriptionAmountBreakdown `json:"amount_with_breakdown,omitempty"`
+	PayerName           *Name                        `json:"payer_name,omitempty"`
+	PayerEmail          string                       `json:"payer_email,omitempty"`
+	Time                string                       `json:"time"`
+	RequestID           string                       `json:"-"`
+}
+
+// SubscriptionAmountBreakdown holds the gross, fee and net amounts of a subscription transaction
+type SubscriptionAmountBreakdown struct {
+	GrossAmount *Money `json:"gross_amount,omitempty"`
+	FeeAmount   *Money `json:"fee_amount,omitempty"`
+	NetAmount   *Money `json:"net_amount,omitempty"`
+}
+
+// SubscriptionTransactionList is a page of subscription transactions
+type SubscriptionTransactionList struct {
+	Transactions []SubscriptionTransaction `json:"transactions"`
+	TotalItems   int                       `json:"total_items,omitempty"`
+	TotalPages   int                       `json:"total_pages,omitempty"`
+	Links        Links                     `json:"links,omitempty"`
+}
+
+// Query returns the list parameters encoded as an URL query
+func (p PlanListParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.ProductID != "" {
+		query.Set("product_id", p.ProductID)
+	}
+
+	if len(p.PlanIDs) > 0 {
+		query.Set("plan_ids", strings.Join(p.PlanIDs, ","))
+	}
+
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+
+	if p.TotalRequired {
+		query.Set("total_required", "true")
+	}
+
+	return query
+}
+
+// LinkByRel returns the subscription link with the given relation
+func (s *Subscription) LinkByRel(rel string) (Link, bool) {
+	return s.Links.ByRel(rel)
+}
+
+// ApprovalURL returns the URL the subscriber must be redirected to in order to approve the subscription
+func (s *Subscription) ApprovalURL() string {
+	return s.Links.ApprovalURL()
+}
+
+// CreatePlan creates a billing plan for a catalog product
+func (c Client) CreatePlan(plan Plan) (*Plan, error) {
+	return c.CreatePlanWithContext(context.Background(), plan)
+}
+
+// CreatePlanWithContext creates a billing plan for a catalog product using the given context
+func (c Client) CreatePlanWithContext(ctx context.Context, plan Plan) (*Plan, error) {
+	return c.planRequest(ctx, http.MethodPost, PlansURL, &plan)
+}
+
+// ListPlans gets a page of the plans matching the given parameters
+func (c Client) ListPlans(params PlanListParams) (*PlanList, error) {
+	return c.ListPlansWithContext(context.Background(), params)
+}
+
+// ListPlansWithContext gets a page of the plans matching the given parameters using the given context
+func (c Client) ListPlansWithContext(ctx context.Context, params PlanListParams) (*PlanList, error) {
+	endpoint := PlansURL
+
+	if query := params.Query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	// Hold plan list response
+	d := PlanList{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, endpoint, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// GetPlan gets the details of the plan with the given ID
+func (c Client) GetPlan(planID string) (*Plan, error) {
+	return c.GetPlanWithContext(context.Background(), planID)
+}
+
+// GetPlanWithContext gets the details of the plan with the given ID using the given context
+func (c Client) GetPlanWithContext(ctx context.Context, planID string) (*Plan, error) {
+	return c.planRequest(ctx, http.MethodGet, fmt.Sprintf(PlanURL, planID), nil)
+}
+
+// UpdatePlanPricing replaces the pricing schemes of the given billing cycles of the plan with the given ID
+func (c Client) UpdatePlanPricing(planID string, schemes []PricingSchemeUpdate) error {
+	return c.UpdatePlanPricingWithContext(context.Background(), planID, schemes)
+}
+
+// UpdatePlanPricingWithContext replaces the pricing schemes of the given billing cycles of the plan
+// with the given ID using the given context
+func (c Client) UpdatePlanPricingWithContext(ctx context.Context, planID string, schemes []PricingSchemeUpdate) error {
+	req := struct {
+		PricingSchemes []PricingSchemeUpdate `json:"pricing_schemes"`
+	}{
+		PricingSchemes: schemes,
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(PlanUpdatePricingURL, planID), &req, nil)
+
+	return err
+}
+
+// ActivatePlan activates the plan with the given ID so new subscriptions can be created
+func (c Client) ActivatePlan(planID string) error {
+	return c.ActivatePlanWithContext(context.Background(), planID)
+}
+
+// ActivatePlanWithContext activates the plan with the given ID using the given context
+func (c Client) ActivatePlanWithContext(ctx context.Context, planID string) error {
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(PlanActivateURL, planID), nil, nil)
+
+	return err
+}
+
+// DeactivatePlan deactivates the plan with the given ID. Existing subscriptions are not affected
+func (c Client) DeactivatePlan(planID string) error {
+	return c.DeactivatePlanWithContext(context.Background(), planID)
+}
+
+// DeactivatePlanWithContext deactivates the plan with the given ID using the given context
+func (c Client) DeactivatePlanWithContext(ctx context.Context, planID string) error {
+	_, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(PlanDeactivateURL, planID), nil, nil)
+
+	return err
+}
+
+// CreateSubscription creates a subscription to a plan. The subscriber must approve it on the
+// subscription approval URL
+func (c Client) CreateSubscription(subscription Subscription) (*Subscription, error) {
+	return c.CreateSubscriptionWithContext(context.Background(), subscription)
+}
+
+// CreateSubscriptionWithContext creates a subscription to a plan using the given context
+func (c Client) CreateSubscriptionWithContext(ctx context.Context, subscription Subscription) (*Subscription, error) {
+	return c.subscriptionRequest(ctx, http.MethodPost, SubscriptionsURL, &subscription)
+}
+
+// GetSubscription gets the details of the subscription with the given ID
+func (c Client) GetSubscription(subscriptionID string) (*Subscription, error) {
+	return c.GetSubscriptionWithContext(context.Background(), subscriptionID)
+}
+
+// GetSubscriptionWithContext gets the details of the subscription with the given ID using the given context
+func (c Client) GetSubscriptionWithContext(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	return c.subscriptionRequest(ctx, http.MethodGet, fmt.Sprintf(SubscriptionURL, subscriptionID), nil)
+}
+
+// ReviseSubscription changes the plan, quantity or shipping of the subscription with the given ID
+func (c Client) ReviseSubscription(subscriptionID string, revision SubscriptionRevision) (*SubscriptionRevision, error) {
+	return c.ReviseSubscriptionWithContext(context.Background(), subscriptionID, revision)
+}
+
+// ReviseSubscriptionWithContext changes the plan, quantity or shipping of the subscription with the
+// given ID using the given context
+func (c Client) ReviseSubscriptionWithContext(ctx context.Context, subscriptionID string, revision SubscriptionRevision) (*SubscriptionRevision, error) {
+	// Hold revision response
+	d := SubscriptionRevision{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(SubscriptionReviseURL, subscriptionID), &revision, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// SuspendSubscription suspends the subscription with the given ID for the given reason
+func (c Client) SuspendSubscription(subscriptionID, reason string) error {
+	return c.SuspendSubscriptionWithContext(context.Background(), subscriptionID, reason)
+}
+
+// SuspendSubscriptionWithContext suspends the subscription with the given ID for the given reason
+// using the given context
+func (c Client) SuspendSubscriptionWithContext(ctx context.Context, subscriptionID, reason string) error {
+	return c.subscriptionAction(ctx, fmt.Sprintf(SubscriptionSuspendURL, subscriptionID), reason)
+}
+
+// ActivateSubscription activates the suspended subscription with the given ID for the given reason
+func (c Client) ActivateSubscription(subscriptionID, reason string) error {
+	return c.ActivateSubscriptionWithContext(context.Background(), subscriptionID, reason)
+}
+
+// ActivateSubscriptionWithContext activates the suspended subscription with the given ID for the
+// given reason using the given context
+func (c Client) ActivateSubscriptionWithContext(ctx context.Context, subscriptionID, reason string) error {
+	return c.subscriptionAction(ctx, fmt.Sprintf(SubscriptionActivateURL, subscriptionID), reason)
+}
+
+// CancelSubscription cancels the subscription with the given ID for the given reason
+func (c Client) CancelSubscription(subscriptionID, reason string) error {
+	return c.CancelSubscriptionWithContext(context.Background(), subscriptionID, reason)
+}
+
+// CancelSubscriptionWithContext cancels the subscription with the given ID for the given reason
+// using the given context
+func (c Client) CancelSubscriptionWithContext(ctx context.Context, subscriptionID, reason string) error {
+	return c.subscriptionAction(ctx, fmt.Sprintf(SubscriptionCancelURL, subscriptionID), reason)
+}
+
+// CaptureSubscription bills the given amount of the outstanding balance of the subscription with
+// the given ID
+func (c Client) CaptureSubscription(subscriptionID, note string, amount Money) (*SubscriptionTransaction, error) {
+	return c.CaptureSubscriptionWithContext(context.Background(), subscriptionID, note, amount)
+}
+
+// CaptureSubscriptionWithContext bills the given amount of the outstanding balance of the
+// subscription with the given ID using the given context
+func (c Client) CaptureSubscriptionWithContext(ctx context.Context, subscriptionID, note string, amount Money) (*SubscriptionTransaction, error) {
+	req := struct {
+		Note        string `json:"note"`
+		CaptureType string `json:"capture_type"`
+		Amount      Money  `json:"amount"`
+	}{
+		Note:        note,
+		CaptureType: "OUTSTANDING_BALANCE",
+		Amount:      amount,
+	}
+
+	// Hold transaction response
+	d := SubscriptionTransaction{}
+
+	id, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(SubscriptionCaptureURL, subscriptionID), &req, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// ListSubscriptionTransactions gets the transactions of the subscription with the given ID made
+// between the given times
+func (c Client) ListSubscriptionTransactions(subscriptionID string, start, end time.Time) (*SubscriptionTransactionList, error) {
+	return c.ListSubscriptionTransactionsWithContext(context.Background(), subscriptionID, start, end)
+}
+
+// ListSubscriptionTransactionsWithContext gets the transactions of the subscription with the given
+// ID made between the given times using the given context
+func (c Client) ListSubscriptionTransactionsWithContext(ctx context.Context, subscriptionID string, start, end time.Time) (*SubscriptionTransactionList, error) {
+	query := url.Values{}
+
+	query.Set("start_time", start.UTC().Format(time.RFC3339))
+	query.Set("end_time", end.UTC().Format(time.RFC3339))
+
+	// Hold transaction list response
+	d := SubscriptionTransactionList{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(SubscriptionTransactionsURL, subscriptionID)+"?"+query.Encode(), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// planRequest runs a plan request and returns the resulting plan
+func (c Client) planRequest(ctx context.Context, method, endpoint string, in interface{}) (*Plan, error) {
+	// Hold plan response
+	d := Plan{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, in, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// subscriptionRequest runs a subscription request and returns the resulting subscription
+func (c Client) subscriptionRequest(ctx context.Context, method, endpoint string, in interface{}) (*Subscription, error) {
+	// Hold subscription response
+	d := Subscription{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, in, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// subscriptionAction runs a subscription status change request with the given reason
+func (c Client) subscriptionAction(ctx context.Context, endpoint, reason string) error {
+	req := struct {
+		Reason string `json:"reason"`
+	}{
+		Reason: reason,
+	}
+
+	_, err := c.JSONRequest(ctx, http.MethodPost, endpoint, &req, nil)
+
+	return err
+}
@@ -0,0 +1,352 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_CreatePlan(t *testing.T) {
+	activated := false
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/billing/plans": func(w http.ResponseWriter, r *http.Request) {
+			raw := map[string]interface{}{}
+
+			if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			// Infinite cycles must be sent as zero total cycles
+			cycles := raw["billing_cycles"].([]interface{})
+
+			if len(cycles) != 2 || cycles[1].(map[string]interface{})["total_cycles"] != 0.0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			// The PayPal default of billing the outstanding amount is kept
+			if _, ok := raw["payment_preferences"].(map[string]interface{})["auto_bill_outstanding"]; ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "P-5ML4271244454362WXNWU5NQ", "product_id": "PROD-XXCD1234QWER65782", "status": "CREATED"}`))
+		},
+		"POST /v1/billing/plans/P-5ML4271244454362WXNWU5NQ/activate": func(w http.ResponseWriter, r *http.Request) {
+			activated = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /v1/billing/plans": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("product_id") != "PROD-XXCD1234QWER65782" || r.URL.Query().Get("total_required") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"plans": [{"id": "P-5ML4271244454362WXNWU5NQ", "status": "ACTIVE"}], "total_items": 1, "total_pages": 1}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	plan, err := client.CreatePlan(Plan{
+		ProductID: "PROD-XXCD1234QWER65782",
+		Name:      "Video Streaming Service Plan",
+		BillingCycles: []BillingCycle{
+			{
+				Frequency:   Frequency{IntervalUnit: IntervalMonth, IntervalCount: 1},
+				TenureType:  TenureTrial,
+				Sequence:    1,
+				TotalCycles: 1,
+			},
+			{
+				Frequency:     Frequency{IntervalUnit: IntervalMonth, IntervalCount: 1},
+				TenureType:    TenureRegular,
+				Sequence:      2,
+				PricingScheme: &PricingScheme{FixedPrice: &Money{CurrencyCode: "USD", Value: "10.00"}},
+			},
+		},
+		PaymentPreferences: &PaymentPreferences{PaymentFailureThreshold: 3},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create plan: %v", err)
+		t.FailNow()
+	}
+
+	if plan.ID != "P-5ML4271244454362WXNWU5NQ" || plan.Status != PlanStatusCreated || plan.RequestID == "" {
+		t.Errorf("Unexpected created plan %+v", plan)
+		t.FailNow()
+	}
+
+	if err := client.ActivatePlan(plan.ID); err != nil || !activated {
+		t.Errorf("Cannot activate plan: %v", err)
+		t.FailNow()
+	}
+
+	list, err := client.ListPlans(PlanListParams{ProductID: plan.ProductID, TotalRequired: true})
+
+	if err != nil {
+		t.Errorf("Cannot list plans: %v", err)
+		t.FailNow()
+	}
+
+	if len(list.Plans) != 1 || list.Plans[0].Status != PlanStatusActive {
+		t.Errorf("Unexpected plans %+v", list)
+		t.FailNow()
+	}
+}
+
+func TestClient_SubscriptionLifecycle(t *testing.T) {
+	reasons := map[string]string{}
+
+	action := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			req := struct {
+				Reason string `json:"reason"`
+			}{}
+
+			json.NewDecoder(r.Body).Decode(&req)
+			reasons[name] = req.Reason
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/billing/subscriptions": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"id": "I-BW452GLLEP1G",
+				"plan_id": "P-5ML4271244454362WXNWU5NQ",
+				"status": "APPROVAL_PENDING",
+				"links": [{"href": "https://www.paypal.com/webapps/billing/subscriptions?ba_token=BA-2M539689T3856352J", "rel": "approve", "method": "GET"}]
+			}`))
+		},
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/suspend":  action("suspend"),
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/activate": action("activate"),
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/cancel":   action("cancel"),
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/capture": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "TRANS-1", "status": "COMPLETED", "amount_with_breakdown": {"gross_amount": {"currency_code": "USD", "value": "10.00"}}}`))
+		},
+		"GET /v1/billing/subscriptions/I-BW452GLLEP1G/transactions": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("start_time") != "2018-01-21T07:50:20Z" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"transactions": [{"id": "TRANS-1", "status": "COMPLETED", "time": "2018-03-16T07:40:20.940Z"}], "total_items": 1}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	sub, err := client.CreateSubscription(Subscription{
+		PlanID:     "P-5ML4271244454362WXNWU5NQ",
+		Subscriber: &Subscriber{EmailAddress: "customer@example.com"},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create subscription: %v", err)
+		t.FailNow()
+	}
+
+	if sub.Status != SubscriptionStatusApprovalPending || sub.ApprovalURL() == "" {
+		t.Errorf("Unexpected created subscription %+v", sub)
+		t.FailNow()
+	}
+
+	// Change the subscription status
+	if err := client.SuspendSubscription(sub.ID, "Item out of stock"); err != nil {
+		t.Errorf("Cannot suspend subscription: %v", err)
+		t.FailNow()
+	}
+
+	if err := client.ActivateSubscription(sub.ID, "Item back in stock"); err != nil {
+		t.Errorf("Cannot activate subscription: %v", err)
+		t.FailNow()
+	}
+
+	if err := client.CancelSubscription(sub.ID, "Not satisfied"); err != nil {
+		t.Errorf("Cannot cancel subscription: %v", err)
+		t.FailNow()
+	}
+
+	if reasons["suspend"] != "Item out of stock" || reasons["cancel"] != "Not satisfied" {
+		t.Errorf("Unexpected status change reasons %v", reasons)
+		t.FailNow()
+	}
+
+	// Bill the outstanding balance
+	tx, err := client.CaptureSubscription(sub.ID, "Charging as the balance reached the limit", Money{CurrencyCode: "USD", Value: "10.00"})
+
+	if err != nil {
+		t.Errorf("Cannot capture subscription: %v", err)
+		t.FailNow()
+	}
+
+	if tx.ID != "TRANS-1" || tx.AmountWithBreakdown.GrossAmount.Value != "10.00" {
+		t.Errorf("Unexpected capture transaction %+v", tx)
+		t.FailNow()
+	}
+
+	// List transactions
+	start := time.Date(2018, 1, 21, 7, 50, 20, 0, time.UTC)
+
+	list, err := client.ListSubscriptionTransactions(sub.ID, start, start.AddDate(0, 3, 0))
+
+	if err != nil {
+		t.Errorf("Cannot list subscription transactions: %v", err)
+		t.FailNow()
+	}
+
+	if len(list.Transactions) != 1 || list.Transactions[0].ID != "TRANS-1" {
+		t.Errorf("Unexpected subscription transactions %+v", list)
+		t.FailNow()
+	}
+}
+
+func TestClient_ManagePlan(t *testing.T) {
+	deactivated := false
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/billing/plans/P-5ML4271244454362WXNWU5NQ": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"id": "P-5ML4271244454362WXNWU5NQ",
+				"status": "ACTIVE",
+				"billing_cycles": [{"frequency": {"interval_unit": "MONTH", "interval_count": 1}, "tenure_type": "REGULAR", "sequence": 1, "total_cycles": 0}],
+				"payment_preferences": {"auto_bill_outstanding": false, "payment_failure_threshold": 3}
+			}`))
+		},
+		"POST /v1/billing/plans/P-5ML4271244454362WXNWU5NQ/update-pricing-schemes": func(w http.ResponseWriter, r *http.Request) {
+			req := struct {
+				PricingSchemes []PricingSchemeUpdate `json:"pricing_schemes"`
+			}{}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.PricingSchemes) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if s := req.PricingSchemes[0]; s.BillingCycleSequence != 1 || s.PricingScheme.FixedPrice == nil || s.PricingScheme.FixedPrice.Value != "15.00" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /v1/billing/plans/P-5ML4271244454362WXNWU5NQ/deactivate": func(w http.ResponseWriter, r *http.Request) {
+			deactivated = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get plan
+	plan, err := client.GetPlan("P-5ML4271244454362WXNWU5NQ")
+
+	if err != nil {
+		t.Errorf("Cannot get plan: %v", err)
+		t.FailNow()
+	}
+
+	if plan.Status != PlanStatusActive || len(plan.BillingCycles) != 1 || plan.PaymentPreferences == nil {
+		t.Errorf("Unexpected plan %+v", plan)
+		t.FailNow()
+	}
+
+	if auto := plan.PaymentPreferences.AutoBillOutstanding; auto == nil || *auto {
+		t.Errorf("Unexpected plan auto billing %v", auto)
+		t.FailNow()
+	}
+
+	// Update plan pricing
+	err = client.UpdatePlanPricing(plan.ID, []PricingSchemeUpdate{
+		{BillingCycleSequence: 1, PricingScheme: PricingScheme{FixedPrice: &Money{CurrencyCode: "USD", Value: "15.00"}}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot update plan pricing: %v", err)
+		t.FailNow()
+	}
+
+	// Deactivate plan
+	if err := client.DeactivatePlan(plan.ID); err != nil || !deactivated {
+		t.Errorf("Cannot deactivate plan: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestClient_ReviseSubscription(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/billing/subscriptions/I-BW452GLLEP1G": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"id": "I-BW452GLLEP1G",
+				"plan_id": "P-5ML4271244454362WXNWU5NQ",
+				"status": "ACTIVE",
+				"quantity": "1",
+				"subscriber": {"email_address": "customer@example.com"}
+			}`))
+		},
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/revise": func(w http.ResponseWriter, r *http.Request) {
+			revision := SubscriptionRevision{}
+
+			if err := json.NewDecoder(r.Body).Decode(&revision); err != nil || revision.PlanID != "P-5ML4271244454362WXNWU5NQ" || revision.Quantity != "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{
+				"plan_id": "P-5ML4271244454362WXNWU5NQ",
+				"quantity": "2",
+				"plan_overridden": false,
+				"links": [{"href": "https://www.paypal.com/webapps/billing/subscriptions/update?ba_token=BA-2M539689T3856352J", "rel": "approve", "method": "GET"}]
+			}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Get subscription
+	sub, err := client.GetSubscription("I-BW452GLLEP1G")
+
+	if err != nil {
+		t.Errorf("Cannot get subscription: %v", err)
+		t.FailNow()
+	}
+
+	if sub.Status != SubscriptionStatusActive || sub.Quantity != "1" || sub.Subscriber == nil || sub.Subscriber.EmailAddress != "customer@example.com" {
+		t.Errorf("Unexpected subscription %+v", sub)
+		t.FailNow()
+	}
+
+	// Revise subscription quantity
+	revision, err := client.ReviseSubscription(sub.ID, SubscriptionRevision{PlanID: sub.PlanID, Quantity: "2"})
+
+	if err != nil {
+		t.Errorf("Cannot revise subscription: %v", err)
+		t.FailNow()
+	}
+
+	if revision.Quantity != "2" || revision.RequestID == "" || len(revision.Links) != 1 {
+		t.Errorf("Unexpected subscription revision %+v", revision)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,182 @@
+package gopaypal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultTokenRefreshMargin is the time before expiration an access token is refreshed by default
+const defaultTokenRefreshMargin = time.Minute
+
+// tokenManager holds the client OAuth2 access token. It is safe for concurrent use and shared by
+// every copy of a client, so a token refreshed by one copy is used by all of them
+type tokenManager struct {
+	mu     sync.Mutex
+	token  *OAuthResponse
+	margin time.Duration
+	call   *tokenCall
+	store  TokenStore
+	key    string
+}
+
+// tokenCall is an in-flight access token request waited on by every goroutine needing a token
+type tokenCall struct {
+	done  chan struct{}
+	token *OAuthResponse
+	err   error
+}
+
+// tokenFetcher requests a new access token from the PayPal endpoint
+type tokenFetcher func(ctx context.Context) (*OAuthResponse, error)
+
+// newTokenManager creates and returns an empty token manager storing tokens under the given key
+func newTokenManager(key string) *tokenManager {
+	return &tokenManager{
+		margin: defaultTokenRefreshMargin,
+		key:    key,
+	}
+}
+
+// WithTokenRefreshMargin sets how long before its expiration the access token is refreshed
+func WithTokenRefreshMargin(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tokens.margin = d
+	}
+}
+
+// get returns a valid access token. An expired token is refreshed once across goroutines
+func (m *tokenManager) get(ctx context.Context, fetch tokenFetcher) (*OAuthResponse, error) {
+	m.mu.Lock()
+
+	// Check if the current token is still valid
+	if m.valid(m.token) {
+		t := m.token
+		m.mu.Unlock()
+
+		return t, nil
+	}
+
+	return m.refresh(ctx, "", fetch)
+}
+
+// renew returns a new access token replacing the given stale one. If another goroutine
+// already replaced it the current token is returned
+func (m *tokenManager) renew(ctx context.Context, stale string, fetch tokenFetcher) (*OAuthResponse, error) {
+	m.mu.Lock()
+
+	if m.valid(m.token) && m.token.AccessToken != stale {
+		t := m.token
+		m.mu.Unlock()
+
+		return t, nil
+	}
+
+	return m.refresh(ctx, stale, fetch)
+}
+
+// set replaces the current access token and saves it on the token store
+func (m *tokenManager) set(ctx context.Context, t *OAuthResponse) error {
+	m.mu.Lock()
+	m.token = t
+	m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	return m.store.Set(ctx, m.key, t.AccessToken, t.Expires)
+}
+
+// refresh loads a new access token or joins the load in flight. It must be called with the lock held
+func (m *tokenManager) refresh(ctx context.Context, stale string, fetch tokenFetcher) (*OAuthResponse, error) {
+	call := m.call
+
+	if call == nil {
+		call = &tokenCall{
+			done: make(chan struct{}),
+		}
+
+		m.call = call
+
+		// The request is shared, so it must not be cancelled by the first caller going away
+		go func() {
+			call.token, call.err = m.load(context.WithoutCancel(ctx), stale, fetch)
+
+			m.mu.Lock()
+
+			if call.err == nil {
+				m.token = call.token
+			}
+
+			m.call = nil
+			m.mu.Unlock()
+
+			close(call.done)
+		}()
+	}
+
+	m.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+		return call.token, call.err
+	}
+}
+
+// load returns a valid access token from the token store or requests a new one from the PayPal endpoint
+func (m *tokenManager) load(ctx context.Context, stale string, fetch tokenFetcher) (*OAuthResponse, error) {
+	// Check if another process already stored a valid token
+	if m.store != nil {
+		tkn, expires, err := m.store.Get(ctx, m.key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		t := &OAuthResponse{
+			AccessToken: tkn,
+			TokenType:   "Bearer",
+			ExpiresIn:   int(time.Until(expires) / time.Second),
+			Expires:     expires,
+		}
+
+		if tkn != stale && m.valid(t) {
+			return t, nil
+		}
+	}
+
+	// Request new access token
+	t, err := fetch(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Share the token with other processes
+	if m.store != nil {
+		if err := m.store.Set(ctx, m.key, t.AccessToken, t.Expires); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// valid reports whether the token can be used without being refreshed. The refresh margin is
+// limited to half the token lifetime, so short lived tokens are still used before being refreshed
+func (m *tokenManager) valid(t *OAuthResponse) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	margin := m.margin
+
+	if lifetime := time.Duration(t.ExpiresIn) * time.Second; margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+
+	return time.Now().Add(margin).Before(t.Expires)
+}
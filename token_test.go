@@ -0,0 +1,160 @@
+package gopaypal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_TokenSingleFlight(t *testing.T) {
+	var tokens int32
+
+	// Create a fake PayPal server with a slow token endpoint
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OAuthURL {
+			atomic.AddInt32(&tokens, 1)
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte(`{"access_token": "A21AA", "expires_in": 32400}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "PAY-1"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	wg := sync.WaitGroup{}
+
+	// Get payment information from several goroutines using client copies
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(c Client) {
+			defer wg.Done()
+
+			if _, err := c.PaymentInformation("PAY-1"); err != nil {
+				t.Errorf("Cannot get payment information: %v", err)
+			}
+		}(client)
+	}
+
+	wg.Wait()
+
+	if tokens != 1 {
+		t.Errorf("Unexpected number of token requests. Got %v expected %v", tokens, 1)
+		t.FailNow()
+	}
+}
+
+func TestClient_TokenRefreshMargin(t *testing.T) {
+	var tokens int32
+
+	// Create a fake PayPal server issuing short lived tokens
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OAuthURL {
+			atomic.AddInt32(&tokens, 1)
+			w.Write([]byte(`{"access_token": "A21AA", "expires_in": 60}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "PAY-1"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client refreshing tokens two minutes before expiration
+	client := NewClient(clientID, secret, srv.URL, WithTokenRefreshMargin(2*time.Minute))
+
+	// Tokens living less than the margin are still reused
+	for i := 0; i < 2; i++ {
+		if _, err := client.PaymentInformation("PAY-1"); err != nil {
+			t.Errorf("Cannot get payment information: %v", err)
+			t.FailNow()
+		}
+	}
+
+	if tokens != 1 {
+		t.Errorf("Unexpected number of token requests. Got %v expected %v", tokens, 1)
+		t.FailNow()
+	}
+}
+
+func TestTokenManager_Valid(t *testing.T) {
+	m := newTokenManager("key")
+	now := time.Now()
+
+	tests := []struct {
+		expiresIn int
+		expires   time.Time
+		valid     bool
+	}{
+		{32400, now.Add(2 * time.Minute), true},
+		{32400, now.Add(30 * time.Second), false},
+		{60, now.Add(40 * time.Second), true},
+		{60, now.Add(20 * time.Second), false},
+		{0, now.Add(time.Second), true},
+		{0, now.Add(-time.Second), false},
+	}
+
+	for _, test := range tests {
+		tkn := &OAuthResponse{AccessToken: "A21AA", ExpiresIn: test.expiresIn, Expires: test.expires}
+
+		if m.valid(tkn) != test.valid {
+			t.Errorf("Unexpected token validity for a %vs token expiring in %v. Expected %v", test.expiresIn, test.expires.Sub(now), test.valid)
+		}
+	}
+
+	if m.valid(nil) || m.valid(&OAuthResponse{Expires: now.Add(time.Hour)}) {
+		t.Errorf("Expected missing tokens to be invalid")
+	}
+}
+
+func TestClient_Token(t *testing.T) {
+	var tokens int32
+
+	// Create a fake PayPal server with a slow token endpoint
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokens, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"access_token": "A21AA", "expires_in": 32400}`))
+	}))
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// A caller going away does not cancel the shared token request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.TokenWithContext(ctx); err != context.Canceled {
+		t.Errorf("Unexpected error. Got %v expected %v", err, context.Canceled)
+		t.FailNow()
+	}
+
+	tkn, err := client.Token()
+
+	if err != nil {
+		t.Errorf("Cannot get access token: %v", err)
+		t.FailNow()
+	}
+
+	if tkn.AccessToken != "A21AA" {
+		t.Errorf("Unexpected access token. Got %v expected %v", tkn.AccessToken, "A21AA")
+		t.FailNow()
+	}
+
+	// The current token is reused
+	if _, err := client.Token(); err != nil || tokens != 1 {
+		t.Errorf("Unexpected number of token requests. Got %v expected %v (%v)", tokens, 1, err)
+		t.FailNow()
+	}
+}
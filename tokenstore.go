@@ -0,0 +1,270 @@
+package gopaypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// staleLockAge is the age a file token store lock is considered abandoned at
+	staleLockAge = 10 * time.Second
+
+	// lockRetryDelay is the time waited between attempts to take a file token store lock
+	lockRetryDelay = 10 * time.Millisecond
+)
+
+// TokenStore stores OAuth2 access tokens so they can be shared by several clients or processes.
+// The client consults the store before requesting a new token from the PayPal endpoint. Tokens are
+// stored under the key returned by TokenStoreKey for the client credentials and base URL
+type TokenStore interface {
+	// Get returns the token stored under the given key. An empty token is returned when there is none
+	Get(ctx context.Context, key string) (token string, expires time.Time, err error)
+
+	// Set stores the token under the given key until it expires
+	Set(ctx context.Context, key, token string, expires time.Time) error
+}
+
+// WithTokenStore sets the store used to share the client access token
+func WithTokenStore(store TokenStore) ClientOption {
+	return func(c *Client) {
+		c.tokens.store = store
+	}
+}
+
+// TokenStoreKey returns the key a client with the given client ID and base URL stores its access
+// token under, formatted as "clientID@baseURL". It can be used to seed or inspect a token store
+func TokenStoreKey(clientID, base string) string {
+	return clientID + "@" + base
+}
+
+// storedToken is an access token saved on a token store
+type storedToken struct {
+	AccessToken string    `json:"access_token"`
+	Expires     time.Time `json:"expires"`
+}
+
+// MemoryTokenStore is a token store holding the tokens in memory. It can be shared by several clients
+// or used by tests to seed a fixed token. The zero value is an empty store ready to use
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]storedToken
+}
+
+// NewMemoryTokenStore creates and returns an empty memory token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[string]storedToken{},
+	}
+}
+
+// Get returns the token stored under the given key
+func (s *MemoryTokenStore) Get(ctx context.Context, key string) (string, time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t := s.tokens[key]
+
+	return t.AccessToken, t.Expires, nil
+}
+
+// Set stores the token under the given key
+func (s *MemoryTokenStore) Set(ctx context.Context, key, token string, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens == nil {
+		s.tokens = map[string]storedToken{}
+	}
+
+	s.tokens[key] = storedToken{
+		AccessToken: token,
+		Expires:     expires,
+	}
+
+	return nil
+}
+
+// FileTokenStore is a token store saving the tokens as JSON on the given file, so processes on the
+// same host or sharing a volume can use a single token. The file is replaced atomically on every write,
+// and writes are serialized across processes with a lock file created next to it (the file path
+// followed by ".lock") holding a random owner token. A lock file left behind by a crashed process is
+// removed after staleLockAge, and a lock is only released by the owner still holding it
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStore creates and returns a token store saving the tokens on the given file path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{
+		path: path,
+	}
+}
+
+// Get returns the token stored under the given key
+func (s *FileTokenStore) Get(ctx context.Context, key string) (string, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Read stored tokens
+	tokens, err := s.read()
+
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	t := tokens[key]
+
+	return t.AccessToken, t.Expires, nil
+}
+
+// Set stores the token under the given key
+func (s *FileTokenStore) Set(ctx context.Context, key, token string, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keep other processes from writing the file until the token is saved
+	unlock, err := s.lock(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	// Read stored tokens
+	tokens, err := s.read()
+
+	if err != nil {
+		return err
+	}
+
+	tokens[key] = storedToken{
+		AccessToken: token,
+		Expires:     expires,
+	}
+
+	// Marshal tokens
+	b, err := json.Marshal(tokens)
+
+	if err != nil {
+		return err
+	}
+
+	// Write a temporary file next to the store file
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// Replace the store file
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// lock creates the store lock file, waiting while another process holds it. It returns the function
+// releasing the lock
+func (s *FileTokenStore) lock(ctx context.Context) (func(), error) {
+	path := s.path + ".lock"
+	owner := CreateNonce()
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+
+		if err == nil {
+			// Write the owner token so the lock is only released by its owner
+			_, err := f.WriteString(owner)
+
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+
+			return func() { removeLock(path, owner) }, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		// Remove the lock left behind by a crashed process
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			if b, err := ioutil.ReadFile(path); err == nil {
+				removeLock(path, string(b))
+			}
+
+			continue
+		}
+
+		// Wait for the lock to be released
+		if err := sleep(ctx, lockRetryDelay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// removeLock removes the lock file at the given path if it still holds the given owner token. The
+// lock is renamed away before being checked, so a lock taken meanwhile by another process is put
+// back instead of being removed
+func removeLock(path, owner string) {
+	tmp := path + "." + CreateNonce()
+
+	if err := os.Rename(path, tmp); err != nil {
+		return
+	}
+
+	defer os.Remove(tmp)
+
+	if b, err := ioutil.ReadFile(tmp); err == nil && string(b) == owner {
+		return
+	}
+
+	// Restore the lock of the other process, unless a new one was created in the meantime
+	os.Link(tmp, path)
+}
+
+// read returns the tokens saved on the store file
+func (s *FileTokenStore) read() (map[string]storedToken, error) {
+	tokens := map[string]storedToken{}
+
+	b, err := ioutil.ReadFile(s.path)
+
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) == 0 {
+		return tokens, nil
+	}
+
+	// Unmarshal stored tokens
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,187 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_TokenStore(t *testing.T) {
+	var tokens int32
+
+	// Create a fake PayPal server
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OAuthURL {
+			atomic.AddInt32(&tokens, 1)
+			w.Write([]byte(`{"access_token": "A21AA", "expires_in": 32400}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer A21AA" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"id": "PAY-1"}`))
+	}))
+
+	defer srv.Close()
+
+	// Create a file token store shared by two clients
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+
+	for i := 0; i < 2; i++ {
+		// Create gopaypal client
+		client := NewClient(clientID, secret, srv.URL, WithTokenStore(store))
+
+		if _, err := client.PaymentInformation("PAY-1"); err != nil {
+			t.Errorf("Cannot get payment information: %v", err)
+			t.FailNow()
+		}
+	}
+
+	if tokens != 1 {
+		t.Errorf("Unexpected number of token requests. Got %v expected %v", tokens, 1)
+		t.FailNow()
+	}
+}
+
+func TestMemoryTokenStore_Seed(t *testing.T) {
+	// Create a fake PayPal server without token endpoint
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer seeded" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"id": "PAY-1"}`))
+	}))
+
+	defer srv.Close()
+
+	// Seed a fixed token
+	store := NewMemoryTokenStore()
+
+	store.Set(context.Background(), TokenStoreKey(clientID, srv.URL), "seeded", time.Now().Add(time.Hour))
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL, WithTokenStore(store))
+
+	if _, err := client.PaymentInformation("PAY-1"); err != nil {
+		t.Errorf("Cannot get payment information: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestMemoryTokenStore_ZeroValue(t *testing.T) {
+	store := MemoryTokenStore{}
+
+	if err := store.Set(context.Background(), "key", "A21AA", time.Now().Add(time.Hour)); err != nil {
+		t.Errorf("Cannot set token: %v", err)
+		t.FailNow()
+	}
+
+	if tkn, _, _ := store.Get(context.Background(), "key"); tkn != "A21AA" {
+		t.Errorf("Unexpected stored token. Got %v expected %v", tkn, "A21AA")
+		t.FailNow()
+	}
+}
+
+func TestFileTokenStore_Lock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	wg := sync.WaitGroup{}
+
+	// Stores on the same file behave like separate processes
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(key string) {
+			defer wg.Done()
+
+			if err := NewFileTokenStore(path).Set(context.Background(), key, "A21AA", time.Now().Add(time.Hour)); err != nil {
+				t.Errorf("Cannot set token: %v", err)
+			}
+		}(fmt.Sprint(i))
+	}
+
+	wg.Wait()
+
+	// No write is lost
+	for i := 0; i < 10; i++ {
+		if tkn, _, err := NewFileTokenStore(path).Get(context.Background(), fmt.Sprint(i)); err != nil || tkn != "A21AA" {
+			t.Errorf("Token %v lost: %v", i, err)
+			t.FailNow()
+		}
+	}
+
+	// A held lock blocks writers until their context is done
+	store := NewFileTokenStore(path)
+	unlock, err := store.lock(context.Background())
+
+	if err != nil {
+		t.Errorf("Cannot lock store: %v", err)
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := store.Set(ctx, "key", "A21AA", time.Now()); err != context.DeadlineExceeded {
+		t.Errorf("Unexpected error. Got %v expected %v", err, context.DeadlineExceeded)
+		t.FailNow()
+	}
+
+	unlock()
+
+	// Abandoned locks are removed
+	if _, err := store.lock(context.Background()); err != nil {
+		t.Errorf("Cannot lock store: %v", err)
+		t.FailNow()
+	}
+
+	old := time.Now().Add(-2 * staleLockAge)
+	os.Chtimes(path+".lock", old, old)
+
+	if err := store.Set(context.Background(), "key", "A21AA", time.Now()); err != nil {
+		t.Errorf("Cannot set token over an abandoned lock: %v", err)
+		t.FailNow()
+	}
+
+	// A lock held past its age and taken by another process is not released by its first owner
+	unlock, err = store.lock(context.Background())
+
+	if err != nil {
+		t.Errorf("Cannot lock store: %v", err)
+		t.FailNow()
+	}
+
+	os.Chtimes(path+".lock", old, old)
+
+	release, err := NewFileTokenStore(path).lock(context.Background())
+
+	if err != nil {
+		t.Errorf("Cannot lock store over an abandoned lock: %v", err)
+		t.FailNow()
+	}
+
+	unlock()
+
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Errorf("Lock of another process released: %v", err)
+		t.FailNow()
+	}
+
+	release()
+
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("Lock not released by its owner: %v", err)
+		t.FailNow()
+	}
+}
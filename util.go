@@ -1,8 +1,36 @@
 package gopaypal
 
-import "github.com/dchest/uniuri"
+import (
+	"context"
+
+	"github.com/dchest/uniuri"
+)
+
+// requestIDKey is the context key of the PayPal-Request-Id header value
+type requestIDKey struct{}
 
 // CreateNonce creates and returns an arbitrary ID that may only be used once
 func CreateNonce() string {
 	return uniuri.NewLen(nonceLength)
 }
+
+// CreateRequestID creates and returns a random idempotency key for the PayPal-Request-Id header
+func CreateRequestID() string {
+	return uniuri.NewLen(requestIDLength)
+}
+
+// ContextWithRequestID returns a copy of the context carrying the given idempotency key. Mutating
+// calls made with the returned context send it as the PayPal-Request-Id header, so sending the
+// same call again (e.g. after a timeout) does not repeat the operation. PayPal answers every call
+// sent with a known key with the response of the first one, so the returned context must only be
+// used for retries of a single operation and never shared between different calls
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the idempotency key carried by the context, if any
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
@@ -0,0 +1,247 @@
+package gopaypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// maxWebhookSize is the maximum size of a webhook event body accepted by the handler
+const maxWebhookSize = 1 << 20
+
+// Webhook event types with typed callbacks on WebhookHandler
+const (
+	EventPaymentSaleCompleted            = "PAYMENT.SALE.COMPLETED"
+	EventPaymentSaleDenied               = "PAYMENT.SALE.DENIED"
+	EventPaymentSalePending              = "PAYMENT.SALE.PENDING"
+	EventPaymentSaleRefunded             = "PAYMENT.SALE.REFUNDED"
+	EventPaymentSaleReversed             = "PAYMENT.SALE.REVERSED"
+	EventPaymentAuthorizationCreated     = "PAYMENT.AUTHORIZATION.CREATED"
+	EventPaymentAuthorizationVoided      = "PAYMENT.AUTHORIZATION.VOIDED"
+	EventPaymentCaptureCompleted         = "PAYMENT.CAPTURE.COMPLETED"
+	EventPaymentCaptureDenied            = "PAYMENT.CAPTURE.DENIED"
+	EventPaymentCaptureRefunded          = "PAYMENT.CAPTURE.REFUNDED"
+	EventCheckoutOrderApproved           = "CHECKOUT.ORDER.APPROVED"
+	EventCheckoutOrderCompleted          = "CHECKOUT.ORDER.COMPLETED"
+	EventCheckoutPaymentApprovalReversed = "CHECKOUT.PAYMENT-APPROVAL.REVERSED"
+)
+
+// errMalformedWebhookEvent is returned when a webhook event or its resource cannot be decoded
+var errMalformedWebhookEvent = errors.New("malformed webhook event")
+
+// webhookEventKey is the context key of the webhook event being handled
+type webhookEventKey struct{}
+
+// WebhookEvent is a webhook event notification sent by PayPal. Resource holds the raw resource the
+// event is about, decoded with DecodeResource
+type WebhookEvent struct {
+	ID              string          `json:"id"`
+	CreateTime      string          `json:"create_time"`
+	ResourceType    string          `json:"resource_type"`
+	EventType       string          `json:"event_type"`
+	EventVersion    string          `json:"event_version,omitempty"`
+	ResourceVersion string          `json:"resource_version,omitempty"`
+	Summary         string          `json:"summary,omitempty"`
+	Resource        json.RawMessage `json:"resource"`
+	Links           Links           `json:"links,omitempty"`
+}
+
+// WebhookEventFunc handles a webhook event
+type WebhookEventFunc func(ctx context.Context, event *WebhookEvent) error
+
+// DecodeResource decodes the event resource into the given value
+func (e *WebhookEvent) DecodeResource(v interface{}) error {
+	if err := json.Unmarshal(e.Resource, v); err != nil {
+		return fmt.Errorf("%w: cannot decode %v resource: %v", errMalformedWebhookEvent, e.ResourceType, err)
+	}
+
+	return nil
+}
+
+// WebhookEventFromContext returns the webhook event being handled, if any. Typed callbacks can use
+// it to get the event envelope, e.g. its ID to discard duplicated deliveries
+func WebhookEventFromContext(ctx context.Context) (*WebhookEvent, bool) {
+	e, ok := ctx.Value(webhookEventKey{}).(*WebhookEvent)
+
+	return e, ok
+}
+
+// WebhookHandler is an http.Handler receiving the webhook events of a PayPal webhook. Events are
+// verified and dispatched to the callback registered for their type. It responds with:
+//
+//   - 200 when the event is handled or has no callback registered
+//   - 400 when the event cannot be decoded
+//   - 403 when the event signature is invalid
+//   - 500 when the callback fails or the signature cannot be checked, so PayPal sends it again
+type WebhookHandler struct {
+	webhookID string
+	verifier  *WebhookVerifier
+
+	mu        sync.RWMutex
+	callbacks map[string]WebhookEventFunc
+}
+
+// NewWebhookHandler creates and returns a handler for the events of the webhook with the given ID.
+// Events are verified with the given verifier, or the DefaultWebhookVerifier when nil
+func NewWebhookHandler(webhookID string, verifier *WebhookVerifier) *WebhookHandler {
+	if verifier == nil {
+		verifier = DefaultWebhookVerifier
+	}
+
+	return &WebhookHandler{
+		webhookID: webhookID,
+		verifier:  verifier,
+		callbacks: map[string]WebhookEventFunc{},
+	}
+}
+
+// On registers the callback of the given event type, replacing any previous one
+func (h *WebhookHandler) On(eventType string, fn WebhookEventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.callbacks[eventType] = fn
+}
+
+// ServeHTTP verifies, decodes and dispatches the webhook event
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Read event body
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Verify event signature
+	if err := h.verifier.Verify(r.Context(), h.webhookID, r.Header, body); err != nil {
+		if errors.Is(err, ErrInvalidWebhookSignature) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Decode event
+	event := WebhookEvent{}
+
+	if err := json.Unmarshal(body, &event); err != nil || event.EventType == "" {
+		http.Error(w, errMalformedWebhookEvent.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Dispatch event
+	h.mu.RLock()
+	fn, ok := h.callbacks[event.EventType]
+	h.mu.RUnlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), webhookEventKey{}, &event)
+
+	if err := fn(ctx, &event); err != nil {
+		if errors.Is(err, errMalformedWebhookEvent) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// OnPaymentSaleCompleted registers the callback of the completed sales of v1 payments
+func (h *WebhookHandler) OnPaymentSaleCompleted(fn func(ctx context.Context, sale *Sale) error) {
+	onResource(h, EventPaymentSaleCompleted, fn)
+}
+
+// OnPaymentSaleDenied registers the callback of the denied sales of v1 payments
+func (h *WebhookHandler) OnPaymentSaleDenied(fn func(ctx context.Context, sale *Sale) error) {
+	onResource(h, EventPaymentSaleDenied, fn)
+}
+
+// OnPaymentSalePending registers the callback of the pending sales of v1 payments
+func (h *WebhookHandler) OnPaymentSalePending(fn func(ctx context.Context, sale *Sale) error) {
+	onResource(h, EventPaymentSalePending, fn)
+}
+
+// OnPaymentSaleRefunded registers the callback of the refunds of v1 payment sales
+func (h *WebhookHandler) OnPaymentSaleRefunded(fn func(ctx context.Context, refund *Refund) error) {
+	onResource(h, EventPaymentSaleRefunded, fn)
+}
+
+// OnPaymentSaleReversed registers the callback of the sales reversed by PayPal
+func (h *WebhookHandler) OnPaymentSaleReversed(fn func(ctx context.Context, refund *Refund) error) {
+	onResource(h, EventPaymentSaleReversed, fn)
+}
+
+// OnPaymentAuthorizationCreated registers the callback of the created payment authorizations
+func (h *WebhookHandler) OnPaymentAuthorizationCreated(fn func(ctx context.Context, auth *AuthorizedPayment) error) {
+	onResource(h, EventPaymentAuthorizationCreated, fn)
+}
+
+// OnPaymentAuthorizationVoided registers the callback of the voided payment authorizations
+func (h *WebhookHandler) OnPaymentAuthorizationVoided(fn func(ctx context.Context, auth *AuthorizedPayment) error) {
+	onResource(h, EventPaymentAuthorizationVoided, fn)
+}
+
+// OnPaymentCaptureCompleted registers the callback of the completed payment captures
+func (h *WebhookHandler) OnPaymentCaptureCompleted(fn func(ctx context.Context, capture *CapturedPayment) error) {
+	onResource(h, EventPaymentCaptureCompleted, fn)
+}
+
+// OnPaymentCaptureDenied registers the callback of the denied payment captures
+func (h *WebhookHandler) OnPaymentCaptureDenied(fn func(ctx context.Context, capture *CapturedPayment) error) {
+	onResource(h, EventPaymentCaptureDenied, fn)
+}
+
+// OnPaymentCaptureRefunded registers the callback of the refunds of payment captures
+func (h *WebhookHandler) OnPaymentCaptureRefunded(fn func(ctx context.Context, refund *PaymentRefund) error) {
+	onResource(h, EventPaymentCaptureRefunded, fn)
+}
+
+// OnCheckoutOrderApproved registers the callback of the orders approved by the buyer
+func (h *WebhookHandler) OnCheckoutOrderApproved(fn func(ctx context.Context, order *Order) error) {
+	onResource(h, EventCheckoutOrderApproved, fn)
+}
+
+// OnCheckoutOrderCompleted registers the callback of the completed orders
+func (h *WebhookHandler) OnCheckoutOrderCompleted(fn func(ctx context.Context, order *Order) error) {
+	onResource(h, EventCheckoutOrderCompleted, fn)
+}
+
+// OnCheckoutPaymentApprovalReversed registers the callback of the orders whose payment approval
+// was reversed before being captured
+func (h *WebhookHandler) OnCheckoutPaymentApprovalReversed(fn func(ctx context.Context, order *Order) error) {
+	onResource(h, EventCheckoutPaymentApprovalReversed, fn)
+}
+
+// onResource registers a callback decoding the event resource into a new T
+func onResource[T any](h *WebhookHandler, eventType string, fn func(ctx context.Context, resource *T) error) {
+	h.On(eventType, func(ctx context.Context, e *WebhookEvent) error {
+		resource := new(T)
+
+		if err := e.DecodeResource(resource); err != nil {
+			return err
+		}
+
+		return fn(ctx, resource)
+	})
+}
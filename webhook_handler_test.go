@@ -0,0 +1,149 @@
+package gopaypal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// postWebhookEvent posts the given webhook event to the handler and returns the response status
+func postWebhookEvent(h http.Handler, header http.Header, body []byte) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/paypal", bytes.NewReader(body))
+	req.Header = header
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestWebhookHandler_Dispatch(t *testing.T) {
+	signer := newWebhookSigner(t)
+	h := NewWebhookHandler("1JE4291016473214C", signer.verifier())
+
+	var sale *Sale
+	var event *WebhookEvent
+
+	h.OnPaymentSaleCompleted(func(ctx context.Context, s *Sale) error {
+		sale = s
+		event, _ = WebhookEventFromContext(ctx)
+
+		return nil
+	})
+
+	body := []byte(`{
+		"id": "WH-2WR32451HC0233532-67976317FL4543714",
+		"create_time": "2014-10-23T17:23:52Z",
+		"resource_type": "sale",
+		"event_type": "PAYMENT.SALE.COMPLETED",
+		"resource": {"id": "80021663DE681814L", "state": "completed", "parent_payment": "PAY-1PA12106FU478450MKRETS4A", "amount": {"total": "0.48", "currency": "USD"}}
+	}`)
+
+	if status := postWebhookEvent(h, signer.sign(t, "1JE4291016473214C", body), body); status != http.StatusOK {
+		t.Errorf("Unexpected status. Got %v expected %v", status, http.StatusOK)
+		t.FailNow()
+	}
+
+	if sale == nil || sale.ID != "80021663DE681814L" || sale.Amount.Total != "0.48" {
+		t.Errorf("Unexpected dispatched sale %+v", sale)
+		t.FailNow()
+	}
+
+	if event == nil || event.ID != "WH-2WR32451HC0233532-67976317FL4543714" {
+		t.Errorf("Unexpected context event %+v", event)
+		t.FailNow()
+	}
+
+	// Events without callback are acknowledged
+	body = []byte(`{"id": "WH-1", "event_type": "CUSTOMER.DISPUTE.CREATED", "resource": {}}`)
+
+	if status := postWebhookEvent(h, signer.sign(t, "1JE4291016473214C", body), body); status != http.StatusOK {
+		t.Errorf("Unexpected unhandled event status. Got %v expected %v", status, http.StatusOK)
+		t.FailNow()
+	}
+}
+
+func TestWebhookHandler_Status(t *testing.T) {
+	signer := newWebhookSigner(t)
+	h := NewWebhookHandler("1JE4291016473214C", signer.verifier())
+
+	h.OnCheckoutOrderApproved(func(ctx context.Context, o *Order) error {
+		if o.ID == "FAIL" {
+			return errors.New("database unavailable")
+		}
+
+		return nil
+	})
+
+	tests := []struct {
+		name     string
+		body     string
+		forge    bool
+		expected int
+	}{
+		{"approved", `{"id": "WH-1", "event_type": "CHECKOUT.ORDER.APPROVED", "resource": {"id": "5O190127TN364715T", "status": "APPROVED"}}`, false, http.StatusOK},
+		{"callback error", `{"id": "WH-2", "event_type": "CHECKOUT.ORDER.APPROVED", "resource": {"id": "FAIL"}}`, false, http.StatusInternalServerError},
+		{"malformed resource", `{"id": "WH-3", "event_type": "CHECKOUT.ORDER.APPROVED", "resource": {"id": 42}}`, false, http.StatusBadRequest},
+		{"malformed event", `{"id": "WH-4"`, false, http.StatusBadRequest},
+		{"forged", `{"id": "WH-5", "event_type": "CHECKOUT.ORDER.APPROVED", "resource": {}}`, true, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		body := []byte(test.body)
+		header := signer.sign(t, "1JE4291016473214C", body)
+
+		if test.forge {
+			body = append(body, ' ')
+		}
+
+		if status := postWebhookEvent(h, header, body); status != test.expected {
+			t.Errorf("Unexpected %v status. Got %v expected %v", test.name, status, test.expected)
+			t.FailNow()
+		}
+	}
+
+	// Only POST requests are accepted
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks/paypal", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Unexpected GET status. Got %v expected %v", rec.Code, http.StatusMethodNotAllowed)
+		t.FailNow()
+	}
+}
+
+func TestWebhookHandler_CaptureRefunded(t *testing.T) {
+	signer := newWebhookSigner(t)
+	h := NewWebhookHandler("1JE4291016473214C", signer.verifier())
+
+	var refund *PaymentRefund
+
+	h.OnPaymentCaptureRefunded(func(ctx context.Context, r *PaymentRefund) error {
+		refund = r
+
+		return nil
+	})
+
+	body := []byte(`{"id": "WH-1", "event_type": "PAYMENT.CAPTURE.REFUNDED", "resource": {"id": "1JU08902781691411", "status": "COMPLETED"}}`)
+
+	if status := postWebhookEvent(h, signer.sign(t, "1JE4291016473214C", body), body); status != http.StatusOK {
+		t.Errorf("Unexpected status. Got %v expected %v", status, http.StatusOK)
+		t.FailNow()
+	}
+
+	if refund == nil || refund.ID != "1JU08902781691411" {
+		t.Errorf("Unexpected dispatched refund %+v", refund)
+		t.FailNow()
+	}
+
+	// Malformed resources are rejected as with every typed callback
+	body = []byte(`{"id": "WH-2", "event_type": "PAYMENT.CAPTURE.REFUNDED", "resource": {"id": 42}}`)
+
+	if status := postWebhookEvent(h, signer.sign(t, "1JE4291016473214C", body), body); status != http.StatusBadRequest {
+		t.Errorf("Unexpected malformed resource status. Got %v expected %v", status, http.StatusBadRequest)
+		t.FailNow()
+	}
+}
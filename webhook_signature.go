@@ -0,0 +1,381 @@
+package gopaypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	// Register the hashes of the supported signature algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+const (
+	// maxCertSize is the maximum size of a fetched webhook signing certificate
+	maxCertSize = 1 << 20
+
+	// maxCachedCerts is the maximum number of signing certificates cached by a webhook verifier
+	maxCachedCerts = 16
+)
+
+// DefaultTransmissionTolerance is how far the transmission time of a webhook event can be from the
+// current time by default. Older events are rejected so captured requests cannot be replayed
+const DefaultTransmissionTolerance = 5 * time.Minute
+
+// ErrInvalidWebhookSignature is returned when a webhook event cannot be proven to come from PayPal
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+var (
+	// DefaultCertHosts are the hosts webhook signing certificates are fetched from by default
+	DefaultCertHosts = []string{
+		"api.paypal.com",
+		"api-m.paypal.com",
+		"api.sandbox.paypal.com",
+		"api-m.sandbox.paypal.com",
+	}
+
+	// DefaultCertNames are the names accepted on webhook signing certificates by default
+	DefaultCertNames = []string{
+		"messageverificationcerts.paypal.com",
+		"messageverificationcerts.sandbox.paypal.com",
+	}
+
+	// DefaultWebhookVerifier is the verifier used by VerifyWebhookSignature
+	DefaultWebhookVerifier = NewWebhookVerifier()
+
+	// certClient fetches the webhook signing certificates. Redirects are not followed, so a
+	// certificate URL on an allowed host cannot send the request to another host
+	certClient = &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return fmt.Errorf("redirect to %v not followed", req.URL)
+		},
+	}
+)
+
+// webhookAlgorithms are the supported PAYPAL-AUTH-ALGO values
+var webhookAlgorithms = map[string]crypto.Hash{
+	"SHA256withRSA": crypto.SHA256,
+	"SHA512withRSA": crypto.SHA512,
+}
+
+// CertFetcher fetches the PEM encoded signing certificate, and its chain, from the given URL
+type CertFetcher func(ctx context.Context, certURL string) ([]byte, error)
+
+// WebhookVerifierOption configures a webhook verifier on creation
+type WebhookVerifierOption func(*WebhookVerifier)
+
+// WebhookVerifier verifies the signature of webhook events locally, without calling the PayPal
+// verify-webhook-signature endpoint. Signing certificates are fetched once and cached by host and
+// path until they expire, keeping at most maxCachedCerts of them. It is safe for concurrent use
+type WebhookVerifier struct {
+	fetch     CertFetcher
+	hosts     []string
+	names     []string
+	roots     *x509.CertPool
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewWebhookVerifier creates and returns a webhook verifier. By default certificates are fetched
+// over HTTPS from DefaultCertHosts and their chain is checked against the system roots
+func NewWebhookVerifier(opts ...WebhookVerifierOption) *WebhookVerifier {
+	v := &WebhookVerifier{
+		fetch:     FetchCert,
+		hosts:     DefaultCertHosts,
+		names:     DefaultCertNames,
+		tolerance: DefaultTransmissionTolerance,
+		now:       time.Now,
+		certs:     map[string]*x509.Certificate{},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// WithCertFetcher sets the function used to fetch the signing certificates
+func WithCertFetcher(fetch CertFetcher) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.fetch = fetch
+	}
+}
+
+// WithCertHosts sets the hosts signing certificates can be fetched from. Certificate URLs on any
+// other host are rejected
+func WithCertHosts(hosts ...string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.hosts = hosts
+	}
+}
+
+// WithCertNames sets the names accepted on the signing certificates
+func WithCertNames(names ...string) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.names = names
+	}
+}
+
+// WithCertRoots sets the root certificates the signing certificate chain is checked against
+func WithCertRoots(roots *x509.CertPool) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.roots = roots
+	}
+}
+
+// WithTransmissionTolerance sets how far the transmission time of a webhook event can be from the
+// current time, in either direction to allow for clock skew. Zero disables the check
+func WithTransmissionTolerance(d time.Duration) WebhookVerifierOption {
+	return func(v *WebhookVerifier) {
+		v.tolerance = d
+	}
+}
+
+// VerifyWebhookSignature verifies the webhook event with the given headers and body was sent by
+// PayPal to the webhook with the given ID, using the DefaultWebhookVerifier
+func VerifyWebhookSignature(ctx context.Context, webhookID string, header http.Header, body []byte) error {
+	return DefaultWebhookVerifier.Verify(ctx, webhookID, header, body)
+}
+
+// Verify verifies the webhook event with the given headers and body was sent by PayPal to the
+// webhook with the given ID. Forged, altered or stale events return ErrInvalidWebhookSignature
+func (v *WebhookVerifier) Verify(ctx context.Context, webhookID string, header http.Header, body []byte) error {
+	// Get transmission headers
+	id := header.Get(TransmissionIDHeader)
+	timestamp := header.Get(TransmissionTimeHeader)
+	sig := header.Get(TransmissionSigHeader)
+	certURL := header.Get(CertURLHeader)
+	algo := header.Get(AuthAlgoHeader)
+
+	if id == "" || timestamp == "" || sig == "" || certURL == "" || algo == "" {
+		return fmt.Errorf("%w: missing transmission headers", ErrInvalidWebhookSignature)
+	}
+
+	// Reject stale transmissions, so captured events cannot be replayed
+	if v.tolerance > 0 {
+		sent, err := time.Parse(time.RFC3339, timestamp)
+
+		if err != nil {
+			return fmt.Errorf("%w: malformed transmission time %v", ErrInvalidWebhookSignature, timestamp)
+		}
+
+		if d := v.now().Sub(sent); d > v.tolerance || d < -v.tolerance {
+			return fmt.Errorf("%w: transmission time %v out of tolerance", ErrInvalidWebhookSignature, timestamp)
+		}
+	}
+
+	hash, ok := webhookAlgorithms[algo]
+
+	if !ok {
+		return fmt.Errorf("%w: unsupported algorithm %v", ErrInvalidWebhookSignature, algo)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(sig)
+
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidWebhookSignature)
+	}
+
+	// Get signing certificate
+	cert, err := v.certificate(ctx, certURL)
+
+	if err != nil {
+		return err
+	}
+
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+
+	if !ok {
+		return fmt.Errorf("%w: certificate key is not RSA", ErrInvalidWebhookSignature)
+	}
+
+	// Verify the signature of the transmission message
+	h := hash.New()
+	h.Write([]byte(WebhookMessage(id, timestamp, webhookID, body)))
+
+	if err := rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookSignature, err)
+	}
+
+	return nil
+}
+
+// WebhookMessage returns the message signed by PayPal for a webhook event:
+// transmission_id|timestamp|webhook_id|crc32(body), with the CRC32 checksum in decimal
+func WebhookMessage(transmissionID, timestamp, webhookID string, body []byte) string {
+	return fmt.Sprintf("%v|%v|%v|%d", transmissionID, timestamp, webhookID, crc32.ChecksumIEEE(body))
+}
+
+// certificate returns the verified signing certificate on the given URL, fetching it if not cached
+func (v *WebhookVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	now := v.now()
+
+	u, err := v.checkCertURL(certURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Check cached certificate. The query is left out of the key, so it cannot be varied to grow the cache
+	key := strings.ToLower(u.Host) + u.EscapedPath()
+
+	v.mu.Lock()
+	cert, ok := v.certs[key]
+	v.mu.Unlock()
+
+	if ok && now.Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	// Fetch certificate chain
+	b, err := v.fetch(ctx, certURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch webhook certificate: %w", err)
+	}
+
+	chain := []*x509.Certificate{}
+
+	for block, rest := pem.Decode(b); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(block.Bytes)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookSignature, err)
+		}
+
+		chain = append(chain, c)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%w: no certificate found on %v", ErrInvalidWebhookSignature, certURL)
+	}
+
+	// Check certificate chain and name
+	cert = chain[0]
+	intermediates := x509.NewCertPool()
+
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookSignature, err)
+	}
+
+	if !v.validName(cert) {
+		return nil, fmt.Errorf("%w: unexpected certificate name %v", ErrInvalidWebhookSignature, cert.Subject.CommonName)
+	}
+
+	// Cache certificate
+	v.mu.Lock()
+	v.cache(key, cert, now)
+	v.mu.Unlock()
+
+	return cert, nil
+}
+
+// cache stores the certificate under the given key. When the cache is full expired certificates are
+// dropped first, then any other one. It must be called with the lock held
+func (v *WebhookVerifier) cache(key string, cert *x509.Certificate, now time.Time) {
+	if _, ok := v.certs[key]; !ok && len(v.certs) >= maxCachedCerts {
+		for k, c := range v.certs {
+			if !now.Before(c.NotAfter) {
+				delete(v.certs, k)
+			}
+		}
+
+		for k := range v.certs {
+			if len(v.certs) < maxCachedCerts {
+				break
+			}
+
+			delete(v.certs, k)
+		}
+	}
+
+	v.certs[key] = cert
+}
+
+// checkCertURL checks the certificate URL is served over HTTPS by an allowed host and returns it parsed
+func (v *WebhookVerifier) checkCertURL(certURL string) (*url.URL, error) {
+	u, err := url.Parse(certURL)
+
+	if err != nil || u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: invalid certificate URL %v", ErrInvalidWebhookSignature, certURL)
+	}
+
+	for _, host := range v.hosts {
+		if strings.EqualFold(u.Host, host) {
+			return u, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: certificate host %v not allowed", ErrInvalidWebhookSignature, u.Host)
+}
+
+// validName reports whether the certificate is issued to one of the accepted names
+func (v *WebhookVerifier) validName(cert *x509.Certificate) bool {
+	for _, name := range v.names {
+		if strings.EqualFold(cert.Subject.CommonName, name) {
+			return true
+		}
+
+		for _, dns := range cert.DNSNames {
+			if strings.EqualFold(dns, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FetchCert fetches the certificate on the given URL with an HTTP GET request, without following
+// redirects. It is the default certificate fetcher
+func FetchCert(ctx context.Context, certURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := certClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v", res.Status)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(res.Body, maxCertSize))
+}
@@ -0,0 +1,315 @@
+package gopaypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testCertURL = "https://api.sandbox.paypal.com/v1/notifications/certs/CERT-360caa42-fca2a594-1d93a270"
+
+// webhookSigner signs webhook events as PayPal does with a test certificate chain
+type webhookSigner struct {
+	key     *rsa.PrivateKey
+	chain   []byte
+	roots   *x509.CertPool
+	fetches int
+}
+
+// newWebhookSigner creates a test CA and a signing certificate issued by it
+func newWebhookSigner(t *testing.T) *webhookSigner {
+	// Create CA
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Errorf("Cannot generate CA key: %v", err)
+		t.FailNow()
+	}
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gopaypal test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+
+	if err != nil {
+		t.Errorf("Cannot create CA certificate: %v", err)
+		t.FailNow()
+	}
+
+	ca, _ = x509.ParseCertificate(caDER)
+
+	// Create signing certificate
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Errorf("Cannot generate signing key: %v", err)
+		t.FailNow()
+	}
+
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "messageverificationcerts.sandbox.paypal.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, leaf, ca, &key.PublicKey, caKey)
+
+	if err != nil {
+		t.Errorf("Cannot create signing certificate: %v", err)
+		t.FailNow()
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	return &webhookSigner{
+		key:   key,
+		chain: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+		roots: roots,
+	}
+}
+
+// verifier returns a webhook verifier trusting the signer certificates
+func (s *webhookSigner) verifier() *WebhookVerifier {
+	return NewWebhookVerifier(WithCertRoots(s.roots), WithCertFetcher(func(ctx context.Context, certURL string) ([]byte, error) {
+		s.fetches++
+		return s.chain, nil
+	}))
+}
+
+// sign returns the transmission headers of the given webhook event sent now
+func (s *webhookSigner) sign(t *testing.T, webhookID string, body []byte) http.Header {
+	return s.signAt(t, webhookID, body, time.Now())
+}
+
+// signAt returns the transmission headers of the given webhook event sent at the given time
+func (s *webhookSigner) signAt(t *testing.T, webhookID string, body []byte, sent time.Time) http.Header {
+	id := "dfb3be50-fd74-11e4-8bf3-77339302725b"
+	timestamp := sent.UTC().Format(time.RFC3339)
+
+	sum := sha256.Sum256([]byte(WebhookMessage(id, timestamp, webhookID, body)))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, sum[:])
+
+	if err != nil {
+		t.Errorf("Cannot sign webhook event: %v", err)
+		t.FailNow()
+	}
+
+	h := http.Header{}
+	h.Set(TransmissionIDHeader, id)
+	h.Set(TransmissionTimeHeader, timestamp)
+	h.Set(TransmissionSigHeader, base64.StdEncoding.EncodeToString(sig))
+	h.Set(CertURLHeader, testCertURL)
+	h.Set(AuthAlgoHeader, "SHA256withRSA")
+
+	return h
+}
+
+func TestWebhookMessage(t *testing.T) {
+	msg := WebhookMessage("dfb3be50", "2015-05-12T18:14:14Z", "1JE4291016473214C", []byte(`{"id":"WH-1"}`))
+
+	if msg != "dfb3be50|2015-05-12T18:14:14Z|1JE4291016473214C|838965618" {
+		t.Errorf("Unexpected webhook message %v", msg)
+		t.FailNow()
+	}
+}
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	signer := newWebhookSigner(t)
+	v := signer.verifier()
+
+	body := []byte(`{"id":"WH-1","event_type":"PAYMENT.SALE.COMPLETED"}`)
+	header := signer.sign(t, "1JE4291016473214C", body)
+
+	// Verify a valid event twice
+	for i := 0; i < 2; i++ {
+		if err := v.Verify(context.Background(), "1JE4291016473214C", header, body); err != nil {
+			t.Errorf("Cannot verify webhook event: %v", err)
+			t.FailNow()
+		}
+	}
+
+	if signer.fetches != 1 {
+		t.Errorf("Certificate not cached. Got %v fetches expected 1", signer.fetches)
+		t.FailNow()
+	}
+
+	// Reject altered events
+	if err := v.Verify(context.Background(), "1JE4291016473214C", header, []byte(`{"id":"WH-2"}`)); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("Unexpected altered body error: %v", err)
+		t.FailNow()
+	}
+
+	if err := v.Verify(context.Background(), "OTHER", header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("Unexpected webhook ID error: %v", err)
+		t.FailNow()
+	}
+
+	// Reject certificates from unknown hosts
+	forged := header.Clone()
+	forged.Set(CertURLHeader, "https://attacker.example.com/cert.pem")
+
+	if err := v.Verify(context.Background(), "1JE4291016473214C", forged, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("Unexpected certificate host error: %v", err)
+		t.FailNow()
+	}
+
+	// Reject unsupported algorithms
+	for _, algo := range []string{"MD5withRSA", "SHA1withRSA"} {
+		forged = header.Clone()
+		forged.Set(AuthAlgoHeader, algo)
+
+		if err := v.Verify(context.Background(), "1JE4291016473214C", forged, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("Unexpected %v algorithm error: %v", algo, err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestWebhookVerifier_CertCache(t *testing.T) {
+	signer := newWebhookSigner(t)
+	v := signer.verifier()
+
+	body := []byte(`{"id":"WH-1","event_type":"PAYMENT.SALE.COMPLETED"}`)
+	header := signer.sign(t, "1JE4291016473214C", body)
+
+	// Certificate URLs only differing on the query share the cached certificate
+	for i := 0; i < 3; i++ {
+		header.Set(CertURLHeader, fmt.Sprintf("%v?v=%v", testCertURL, i))
+
+		if err := v.Verify(context.Background(), "1JE4291016473214C", header, body); err != nil {
+			t.Errorf("Cannot verify webhook event: %v", err)
+			t.FailNow()
+		}
+	}
+
+	if signer.fetches != 1 || len(v.certs) != 1 {
+		t.Errorf("Unexpected certificate cache. Got %v fetches and %v certificates expected 1", signer.fetches, len(v.certs))
+		t.FailNow()
+	}
+
+	// The number of cached certificates is limited
+	for i := 0; i < 2*maxCachedCerts; i++ {
+		header.Set(CertURLHeader, fmt.Sprintf("%v-%v", testCertURL, i))
+
+		if err := v.Verify(context.Background(), "1JE4291016473214C", header, body); err != nil {
+			t.Errorf("Cannot verify webhook event: %v", err)
+			t.FailNow()
+		}
+	}
+
+	if len(v.certs) > maxCachedCerts {
+		t.Errorf("Unexpected number of cached certificates. Got %v expected at most %v", len(v.certs), maxCachedCerts)
+		t.FailNow()
+	}
+}
+
+func TestWebhookVerifier_UntrustedChain(t *testing.T) {
+	signer := newWebhookSigner(t)
+
+	// Verify with the system roots, which do not trust the test CA
+	v := NewWebhookVerifier(WithCertFetcher(func(ctx context.Context, certURL string) ([]byte, error) {
+		return signer.chain, nil
+	}))
+
+	body := []byte(`{"id":"WH-1"}`)
+
+	if err := v.Verify(context.Background(), "1JE4291016473214C", signer.sign(t, "1JE4291016473214C", body), body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("Unexpected untrusted chain error: %v", err)
+		t.FailNow()
+	}
+
+	// Reject certificates issued to other names
+	v = NewWebhookVerifier(WithCertRoots(signer.roots), WithCertNames("example.com"), WithCertFetcher(func(ctx context.Context, certURL string) ([]byte, error) {
+		return signer.chain, nil
+	}))
+
+	if err := v.Verify(context.Background(), "1JE4291016473214C", signer.sign(t, "1JE4291016473214C", body), body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("Unexpected certificate name error: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestWebhookVerifier_TransmissionTime(t *testing.T) {
+	signer := newWebhookSigner(t)
+	v := signer.verifier()
+
+	body := []byte(`{"id":"WH-1"}`)
+
+	// Accept events within the tolerance window, allowing for clock skew
+	for _, offset := range []time.Duration{-4 * time.Minute, time.Minute} {
+		if err := v.Verify(context.Background(), "1JE4291016473214C", signer.signAt(t, "1JE4291016473214C", body, time.Now().Add(offset)), body); err != nil {
+			t.Errorf("Cannot verify webhook event sent %v from now: %v", offset, err)
+			t.FailNow()
+		}
+	}
+
+	// Reject replayed and future events
+	for _, offset := range []time.Duration{-time.Hour, 10 * time.Minute} {
+		if err := v.Verify(context.Background(), "1JE4291016473214C", signer.signAt(t, "1JE4291016473214C", body, time.Now().Add(offset)), body); !errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("Unexpected error verifying an event sent %v from now: %v", offset, err)
+			t.FailNow()
+		}
+	}
+
+	// Reject malformed transmission times
+	header := signer.sign(t, "1JE4291016473214C", body)
+	header.Set(TransmissionTimeHeader, "yesterday")
+
+	if err := v.Verify(context.Background(), "1JE4291016473214C", header, body); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("Unexpected malformed transmission time error: %v", err)
+		t.FailNow()
+	}
+
+	// The check can be disabled
+	v = NewWebhookVerifier(WithCertRoots(signer.roots), WithTransmissionTolerance(0), WithCertFetcher(func(ctx context.Context, certURL string) ([]byte, error) {
+		return signer.chain, nil
+	}))
+
+	if err := v.Verify(context.Background(), "1JE4291016473214C", signer.signAt(t, "1JE4291016473214C", body, time.Now().Add(-time.Hour)), body); err != nil {
+		t.Errorf("Cannot verify webhook event without tolerance: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestFetchCert_Redirect(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Certificate redirect followed to %v", r.URL)
+	}))
+
+	defer target.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL+"/cert.pem", http.StatusFound)
+	}))
+
+	defer srv.Close()
+
+	if _, err := FetchCert(context.Background(), srv.URL+"/cert.pem"); err == nil {
+		t.Errorf("Expected an error fetching a redirected certificate")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,334 @@
+package gopaypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Webhook is a webhook subscribing an URL to the given event types. The "*" event type subscribes
+// to every event
+type Webhook struct {
+	ID         string             `json:"id,omitempty"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Links      Links              `json:"links,omitempty"`
+	RequestID  string             `json:"-"`
+}
+
+// WebhookEventType is an event type webhooks can subscribe to
+type WebhookEventType struct {
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	ResourceVersions []string `json:"resource_versions,omitempty"`
+}
+
+// WebhookEventListParams filters and paginates the events returned by ListWebhookEvents. Zero
+// values are not sent
+type WebhookEventListParams struct {
+	PageSize      int
+	StartTime     time.Time
+	EndTime       time.Time
+	TransactionID string
+	EventType     string
+}
+
+// WebhookEventList is a page of webhook events. The next page is found on the next link
+type WebhookEventList struct {
+	Events []WebhookEvent `json:"events"`
+	Count  int            `json:"count"`
+	Links  Links          `json:"links,omitempty"`
+}
+
+// SimulateWebhookEventRequest holds the details of a sample event sent to a webhook. Either the
+// webhook ID or an URL must be set
+type SimulateWebhookEventRequest struct {
+	WebhookID       string `json:"webhook_id,omitempty"`
+	URL             string `json:"url,omitempty"`
+	EventType       string `json:"event_type"`
+	ResourceVersion string `json:"resource_version,omitempty"`
+}
+
+// Query returns the list parameters encoded as an URL query
+func (p WebhookEventListParams) Query() url.Values {
+	query := url.Values{}
+
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	if !p.StartTime.IsZero() {
+		query.Set("start_time", p.StartTime.UTC().Format(time.RFC3339))
+	}
+
+	if !p.EndTime.IsZero() {
+		query.Set("end_time", p.EndTime.UTC().Format(time.RFC3339))
+	}
+
+	if p.TransactionID != "" {
+		query.Set("transaction_id", p.TransactionID)
+	}
+
+	if p.EventType != "" {
+		query.Set("event_type", p.EventType)
+	}
+
+	return query
+}
+
+// CreateWebhook subscribes the webhook URL to the webhook event types
+func (c Client) CreateWebhook(webhook Webhook) (*Webhook, error) {
+	return c.CreateWebhookWithContext(context.Background(), webhook)
+}
+
+// CreateWebhookWithContext subscribes the webhook URL to the webhook event types using the given context
+func (c Client) CreateWebhookWithContext(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	return c.webhookRequest(ctx, http.MethodPost, WebhooksURL, &webhook)
+}
+
+// ListWebhooks gets every webhook of the application
+func (c Client) ListWebhooks() ([]Webhook, error) {
+	return c.ListWebhooksWithContext(context.Background())
+}
+
+// ListWebhooksWithContext gets every webhook of the application using the given context
+func (c Client) ListWebhooksWithContext(ctx context.Context) ([]Webhook, error) {
+	// Hold webhook list response
+	d := struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, WebhooksURL+"?anchor_type=APPLICATION", nil, &d); err != nil {
+		return nil, err
+	}
+
+	return d.Webhooks, nil
+}
+
+// GetWebhook gets the details of the webhook with the given ID
+func (c Client) GetWebhook(webhookID string) (*Webhook, error) {
+	return c.GetWebhookWithContext(context.Background(), webhookID)
+}
+
+// GetWebhookWithContext gets the details of the webhook with the given ID using the given context
+func (c Client) GetWebhookWithContext(ctx context.Context, webhookID string) (*Webhook, error) {
+	return c.webhookRequest(ctx, http.MethodGet, fmt.Sprintf(WebhookURL, webhookID), nil)
+}
+
+// UpdateWebhook updates the URL or the event types of the webhook with the given ID applying the
+// JSON Patch operations
+func (c Client) UpdateWebhook(webhookID string, patch Patch) (*Webhook, error) {
+	return c.UpdateWebhookWithContext(context.Background(), webhookID, patch)
+}
+
+// UpdateWebhookWithContext updates the URL or the event types of the webhook with the given ID
+// applying the JSON Patch operations using the given context
+func (c Client) UpdateWebhookWithContext(ctx context.Context, webhookID string, patch Patch) (*Webhook, error) {
+	// Validate patch operations
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.webhookRequest(ctx, http.MethodPatch, fmt.Sprintf(WebhookURL, webhookID), patch)
+}
+
+// DeleteWebhook deletes the webhook with the given ID
+func (c Client) DeleteWebhook(webhookID string) error {
+	return c.DeleteWebhookWithContext(context.Background(), webhookID)
+}
+
+// DeleteWebhookWithContext deletes the webhook with the given ID using the given context
+func (c Client) DeleteWebhookWithContext(ctx context.Context, webhookID string) error {
+	_, err := c.JSONRequest(ctx, http.MethodDelete, fmt.Sprintf(WebhookURL, webhookID), nil, nil)
+
+	return err
+}
+
+// ReconcileWebhook makes the application webhook with the URL of the given webhook subscribe to
+// exactly its event types, creating the webhook if missing. Webhooks already in sync are not updated
+func (c Client) ReconcileWebhook(webhook Webhook) (*Webhook, error) {
+	return c.ReconcileWebhookWithContext(context.Background(), webhook)
+}
+
+// ReconcileWebhookWithContext makes the application webhook with the URL of the given webhook
+// subscribe to exactly its event types using the given context
+func (c Client) ReconcileWebhookWithContext(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	webhooks, err := c.ListWebhooksWithContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webhooks {
+		if w.URL != webhook.URL {
+			continue
+		}
+
+		if sameEventTypes(w.EventTypes, webhook.EventTypes) {
+			return &w, nil
+		}
+
+		// Only the event type names are sent
+		types := []WebhookEventType{}
+
+		for _, t := range webhook.EventTypes {
+			types = append(types, WebhookEventType{Name: t.Name})
+		}
+
+		return c.UpdateWebhookWithContext(ctx, w.ID, Patch{}.Replace("/event_types", types))
+	}
+
+	return c.CreateWebhookWithContext(ctx, webhook)
+}
+
+// ListWebhookEventTypes gets the event types webhooks can subscribe to
+func (c Client) ListWebhookEventTypes() ([]WebhookEventType, error) {
+	return c.ListWebhookEventTypesWithContext(context.Background())
+}
+
+// ListWebhookEventTypesWithContext gets the event types webhooks can subscribe to using the given context
+func (c Client) ListWebhookEventTypesWithContext(ctx context.Context) ([]WebhookEventType, error) {
+	// Hold event type list response
+	d := struct {
+		EventTypes []WebhookEventType `json:"event_types"`
+	}{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, WebhookEventTypesURL, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return d.EventTypes, nil
+}
+
+// ListWebhookEvents gets a page of the webhook events matching the given parameters
+func (c Client) ListWebhookEvents(params WebhookEventListParams) (*WebhookEventList, error) {
+	return c.ListWebhookEventsWithContext(context.Background(), params)
+}
+
+// ListWebhookEventsWithContext gets a page of the webhook events matching the given parameters
+// using the given context
+func (c Client) ListWebhookEventsWithContext(ctx context.Context, params WebhookEventListParams) (*WebhookEventList, error) {
+	endpoint := WebhookEventsURL
+
+	if query := params.Query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	// Hold event list response
+	d := WebhookEventList{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, endpoint, nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// GetWebhookEvent gets the webhook event with the given ID
+func (c Client) GetWebhookEvent(eventID string) (*WebhookEvent, error) {
+	return c.GetWebhookEventWithContext(context.Background(), eventID)
+}
+
+// GetWebhookEventWithContext gets the webhook event with the given ID using the given context
+func (c Client) GetWebhookEventWithContext(ctx context.Context, eventID string) (*WebhookEvent, error) {
+	// Hold event response
+	d := WebhookEvent{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodGet, fmt.Sprintf(WebhookEventURL, eventID), nil, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// ResendWebhookEvent sends the webhook event with the given ID again to the given webhooks, or to
+// every webhook subscribed to it when none is given
+func (c Client) ResendWebhookEvent(eventID string, webhookIDs ...string) (*WebhookEvent, error) {
+	return c.ResendWebhookEventWithContext(context.Background(), eventID, webhookIDs...)
+}
+
+// ResendWebhookEventWithContext sends the webhook event with the given ID again to the given
+// webhooks using the given context
+func (c Client) ResendWebhookEventWithContext(ctx context.Context, eventID string, webhookIDs ...string) (*WebhookEvent, error) {
+	req := struct {
+		WebhookIDs []string `json:"webhook_ids,omitempty"`
+	}{
+		WebhookIDs: webhookIDs,
+	}
+
+	// Hold event response
+	d := WebhookEvent{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodPost, fmt.Sprintf(WebhookEventResendURL, eventID), &req, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// SimulateWebhookEvent sends a sample event of the given type to a webhook
+func (c Client) SimulateWebhookEvent(req SimulateWebhookEventRequest) (*WebhookEvent, error) {
+	return c.SimulateWebhookEventWithContext(context.Background(), req)
+}
+
+// SimulateWebhookEventWithContext sends a sample event of the given type to a webhook using the given context
+func (c Client) SimulateWebhookEventWithContext(ctx context.Context, req SimulateWebhookEventRequest) (*WebhookEvent, error) {
+	// Hold event response
+	d := WebhookEvent{}
+
+	if _, err := c.JSONRequest(ctx, http.MethodPost, SimulateWebhookEventURL, &req, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// webhookRequest runs a webhook request and returns the resulting webhook
+func (c Client) webhookRequest(ctx context.Context, method, endpoint string, in interface{}) (*Webhook, error) {
+	// Hold webhook response
+	d := Webhook{}
+
+	id, err := c.JSONRequest(ctx, method, endpoint, in, &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.RequestID = id
+
+	return &d, nil
+}
+
+// sameEventTypes reports whether both lists hold the same event type names
+func sameEventTypes(a, b []WebhookEventType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	names := func(types []WebhookEventType) []string {
+		s := []string{}
+
+		for _, t := range types {
+			s = append(s, t.Name)
+		}
+
+		sort.Strings(s)
+
+		return s
+	}
+
+	x, y := names(a), names(b)
+
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
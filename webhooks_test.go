@@ -0,0 +1,310 @@
+package gopaypal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ReconcileWebhook(t *testing.T) {
+	var patch Patch
+	created := false
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/notifications/webhooks": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("anchor_type") != "APPLICATION" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"webhooks": [
+				{"id": "40Y916089Y8324740", "url": "https://example.com/paypal", "event_types": [{"name": "PAYMENT.SALE.COMPLETED"}]},
+				{"id": "0EH40505U7160970P", "url": "https://example.com/other", "event_types": [{"name": "*"}]}
+			]}`))
+		},
+		"PATCH /v1/notifications/webhooks/40Y916089Y8324740": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&patch)
+
+			w.Write([]byte(`{"id": "40Y916089Y8324740", "url": "https://example.com/paypal", "event_types": [
+				{"name": "PAYMENT.SALE.COMPLETED"}, {"name": "PAYMENT.SALE.REFUNDED"}
+			]}`))
+		},
+		"POST /v1/notifications/webhooks": func(w http.ResponseWriter, r *http.Request) {
+			created = true
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "8PT597110X687430LKGECATA", "url": "https://example.com/new", "event_types": [{"name": "*"}]}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Webhooks in sync are not updated
+	res, err := client.ReconcileWebhook(Webhook{
+		URL:        "https://example.com/other",
+		EventTypes: []WebhookEventType{{Name: "*"}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot reconcile webhook: %v", err)
+		t.FailNow()
+	}
+
+	if res.ID != "0EH40505U7160970P" || patch != nil || created {
+		t.Errorf("Unexpected reconciled webhook %+v", res)
+		t.FailNow()
+	}
+
+	// Webhooks out of sync are patched
+	res, err = client.ReconcileWebhook(Webhook{
+		URL:        "https://example.com/paypal",
+		EventTypes: []WebhookEventType{{Name: EventPaymentSaleRefunded}, {Name: EventPaymentSaleCompleted}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot reconcile webhook: %v", err)
+		t.FailNow()
+	}
+
+	if len(patch) != 1 || patch[0].Op != "replace" || patch[0].Path != "/event_types" || len(res.EventTypes) != 2 {
+		t.Errorf("Unexpected webhook patch %+v", patch)
+		t.FailNow()
+	}
+
+	// Missing webhooks are created
+	res, err = client.ReconcileWebhook(Webhook{
+		URL:        "https://example.com/new",
+		EventTypes: []WebhookEventType{{Name: "*"}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot reconcile webhook: %v", err)
+		t.FailNow()
+	}
+
+	if !created || res.ID != "8PT597110X687430LKGECATA" || res.RequestID == "" {
+		t.Errorf("Unexpected created webhook %+v", res)
+		t.FailNow()
+	}
+}
+
+func TestClient_Webhooks(t *testing.T) {
+	deleted := false
+
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"POST /v1/notifications/webhooks": func(w http.ResponseWriter, r *http.Request) {
+			webhook := Webhook{}
+
+			if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil || webhook.URL != "https://example.com/paypal" || len(webhook.EventTypes) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "40Y916089Y8324740", "url": "https://example.com/paypal", "event_types": [{"name": "PAYMENT.SALE.COMPLETED"}]}`))
+		},
+		"GET /v1/notifications/webhooks/40Y916089Y8324740": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "40Y916089Y8324740", "url": "https://example.com/paypal", "event_types": [{"name": "PAYMENT.SALE.COMPLETED", "status": "ENABLED"}]}`))
+		},
+		"PATCH /v1/notifications/webhooks/40Y916089Y8324740": func(w http.ResponseWriter, r *http.Request) {
+			patch := Patch{}
+
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || len(patch) != 1 || patch[0].Path != "/url" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"id": "40Y916089Y8324740", "url": "https://example.com/new", "event_types": [{"name": "PAYMENT.SALE.COMPLETED"}]}`))
+		},
+		"DELETE /v1/notifications/webhooks/40Y916089Y8324740": func(w http.ResponseWriter, r *http.Request) {
+			deleted = true
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /v1/notifications/webhooks-event-types": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"event_types": [{"name": "PAYMENT.SALE.COMPLETED", "description": "A sale completes."}]}`))
+		},
+		"GET /v1/notifications/webhooks": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("anchor_type") != "APPLICATION" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"webhooks": [{"id": "40Y916089Y8324740", "url": "https://example.com/new", "event_types": [{"name": "PAYMENT.SALE.COMPLETED"}]}]}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	// Create webhook
+	webhook, err := client.CreateWebhook(Webhook{
+		URL:        "https://example.com/paypal",
+		EventTypes: []WebhookEventType{{Name: EventPaymentSaleCompleted}},
+	})
+
+	if err != nil {
+		t.Errorf("Cannot create webhook: %v", err)
+		t.FailNow()
+	}
+
+	if webhook.ID != "40Y916089Y8324740" || webhook.RequestID == "" {
+		t.Errorf("Unexpected created webhook %+v", webhook)
+		t.FailNow()
+	}
+
+	// Get webhook
+	webhook, err = client.GetWebhook(webhook.ID)
+
+	if err != nil {
+		t.Errorf("Cannot get webhook: %v", err)
+		t.FailNow()
+	}
+
+	if len(webhook.EventTypes) != 1 || webhook.EventTypes[0].Status != "ENABLED" {
+		t.Errorf("Unexpected webhook event types %+v", webhook.EventTypes)
+		t.FailNow()
+	}
+
+	// Update webhook URL
+	webhook, err = client.UpdateWebhook(webhook.ID, Patch{{Op: "replace", Path: "/url", Value: "https://example.com/new"}})
+
+	if err != nil {
+		t.Errorf("Cannot update webhook: %v", err)
+		t.FailNow()
+	}
+
+	if webhook.URL != "https://example.com/new" {
+		t.Errorf("Unexpected webhook URL. Got %v expected %v", webhook.URL, "https://example.com/new")
+		t.FailNow()
+	}
+
+	// List webhooks
+	webhooks, err := client.ListWebhooks()
+
+	if err != nil {
+		t.Errorf("Cannot list webhooks: %v", err)
+		t.FailNow()
+	}
+
+	if len(webhooks) != 1 || webhooks[0].ID != webhook.ID || webhooks[0].URL != "https://example.com/new" {
+		t.Errorf("Unexpected webhooks %+v", webhooks)
+		t.FailNow()
+	}
+
+	// List available event types
+	types, err := client.ListWebhookEventTypes()
+
+	if err != nil {
+		t.Errorf("Cannot list webhook event types: %v", err)
+		t.FailNow()
+	}
+
+	if len(types) != 1 || types[0].Name != EventPaymentSaleCompleted {
+		t.Errorf("Unexpected webhook event types %+v", types)
+		t.FailNow()
+	}
+
+	// Delete webhook
+	if err := client.DeleteWebhook(webhook.ID); err != nil || !deleted {
+		t.Errorf("Cannot delete webhook: %v", err)
+		t.FailNow()
+	}
+}
+
+func TestClient_WebhookEvents(t *testing.T) {
+	srv := newAPIServer(t, map[string]http.HandlerFunc{
+		"GET /v1/notifications/webhooks-events": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page_size") != "10" || r.URL.Query().Get("event_type") != EventPaymentSaleCompleted {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Write([]byte(`{"events": [
+				{"id": "WH-1", "event_type": "PAYMENT.SALE.COMPLETED", "resource_type": "sale", "resource": {"id": "80021663DE681814L"}}
+			], "count": 1}`))
+		},
+		"POST /v1/notifications/webhooks-events/WH-1/resend": func(w http.ResponseWriter, r *http.Request) {
+			req := struct {
+				WebhookIDs []string `json:"webhook_ids"`
+			}{}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.WebhookIDs) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "WH-1", "event_type": "PAYMENT.SALE.COMPLETED"}`))
+		},
+		"GET /v1/notifications/webhooks-events/WH-1": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "WH-1", "event_type": "PAYMENT.SALE.COMPLETED", "resource_type": "sale", "resource": {"id": "80021663DE681814L"}}`))
+		},
+		"POST /v1/notifications/simulate-event": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "WH-SIM", "event_type": "PAYMENT.SALE.COMPLETED", "summary": "Payment completed"}`))
+		},
+	})
+
+	defer srv.Close()
+
+	// Create gopaypal client
+	client := NewClient(clientID, secret, srv.URL)
+
+	list, err := client.ListWebhookEvents(WebhookEventListParams{PageSize: 10, EventType: EventPaymentSaleCompleted})
+
+	if err != nil {
+		t.Errorf("Cannot list webhook events: %v", err)
+		t.FailNow()
+	}
+
+	sale := Sale{}
+
+	if len(list.Events) != 1 || list.Events[0].DecodeResource(&sale) != nil || sale.ID != "80021663DE681814L" {
+		t.Errorf("Unexpected webhook events %+v", list)
+		t.FailNow()
+	}
+
+	resent, err := client.ResendWebhookEvent("WH-1", "40Y916089Y8324740")
+
+	if err != nil {
+		t.Errorf("Cannot resend webhook event: %v", err)
+		t.FailNow()
+	}
+
+	if resent.ID != "WH-1" || resent.EventType != EventPaymentSaleCompleted {
+		t.Errorf("Unexpected resent event %+v", resent)
+		t.FailNow()
+	}
+
+	event, err := client.GetWebhookEvent("WH-1")
+
+	if err != nil {
+		t.Errorf("Cannot get webhook event: %v", err)
+		t.FailNow()
+	}
+
+	if event.ResourceType != "sale" {
+		t.Errorf("Unexpected event resource type. Got %v expected %v", event.ResourceType, "sale")
+		t.FailNow()
+	}
+
+	event, err = client.SimulateWebhookEvent(SimulateWebhookEventRequest{
+		WebhookID: "40Y916089Y8324740",
+		EventType: EventPaymentSaleCompleted,
+	})
+
+	if err != nil {
+		t.Errorf("Cannot simulate webhook event: %v", err)
+		t.FailNow()
+	}
+
+	if event.ID != "WH-SIM" {
+		t.Errorf("Unexpected simulated event ID. Got %v expected %v", event.ID, "WH-SIM")
+		t.FailNow()
+	}
+}